@@ -1,6 +1,8 @@
 package ugucensor
 
 import (
+	"sort"
+	"strconv"
 	"strings"
 	"unicode"
 	"unicode/utf8"
@@ -16,33 +18,247 @@ type Stemmer interface {
 type Censor struct {
 	dicts    map[string]*trie.Trie
 	stemmers map[string]Stemmer
+
+	// entries maps every key inserted into dicts[lang] back to the
+	// dictionary entry it was generated from.
+	entries map[string]map[string]*entry
+
+	// prefixes holds the per-language prefix tables used by prefix
+	// matching; prefixLangs marks languages having entries that opted in.
+	prefixes       map[string][][]rune
+	prefixLangs    map[string]bool
+	prefixMatching bool
+
+	// folds holds the per-language letter equivalences applied by normalization.
+	folds map[string]map[rune]rune
+
+	expandParadigms bool
+	stripInvisible  bool
+	transliteration bool
+	layoutMatching  bool
+	reversed        bool
+	wordSplitting   bool
+
+	// infixMinLen enables matching entries at least that long inside
+	// longer words, see WithInfixMatching.
+	infixMinLen int
+
+	// allowed holds the normalized words never censored, see AllowWord.
+	allowed map[string]map[string]bool
+
+	// wildcards hide up to maxWildcards letters of a word, see WithWildcards.
+	wildcards    []rune
+	maxWildcards int
+
+	// fuzzyMinLen enables fuzzy matching for entries at least that long;
+	// fuzzyDistances holds the maximum edit distance per severity.
+	fuzzyMinLen    int
+	fuzzyDistances map[Severity]int
+
+	// skeletons indexes the entries by their consonant skeleton when
+	// skeletonMinLen enables skeleton matching; skeletonEntries maps the
+	// skeletons back to the entries.
+	skeletons       map[string]*trie.Trie
+	skeletonEntries map[string]map[string]*entry
+	skeletonMinLen  int
+
+	// htmlAttributes holds the attributes censored by CensorHTML.
+	htmlAttributes map[string]bool
+
+	// minConfidence and fallbackLangs configure CensorAuto.
+	minConfidence float64
+	fallbackLangs []string
+}
+
+// Severity rates how offensive a dictionary entry is.
+type Severity int
+
+const (
+	SeverityLow Severity = iota + 1
+	SeverityMedium
+	SeverityHigh
+)
+
+func (s Severity) String() string {
+	switch s {
+	case SeverityLow:
+		return "low"
+	case SeverityMedium:
+		return "medium"
+	case SeverityHigh:
+		return "high"
+	}
+	return "Severity(" + strconv.Itoa(int(s)) + ")"
+}
+
+// entry is a word added to the dictionary.
+type entry struct {
+	// word is the normalized word as it was added.
+	word     string
+	forms    []string
+	severity Severity
+	category string
+
+	prefixMatching bool
+	fleetingVowel  bool
 }
 
-func NewCensor() *Censor {
-	return &Censor{
+func NewCensor(opts ...Option) *Censor {
+	c := &Censor{
 		dicts:    make(map[string]*trie.Trie),
 		stemmers: make(map[string]Stemmer),
+		entries:  make(map[string]map[string]*entry),
+
+		prefixes:    make(map[string][][]rune),
+		prefixLangs: make(map[string]bool),
+		folds:       make(map[string]map[rune]rune),
+		allowed:     make(map[string]map[string]bool),
+
+		skeletons:       make(map[string]*trie.Trie),
+		skeletonEntries: make(map[string]map[string]*entry),
+
+		minConfidence: 0.5,
+		fuzzyDistances: map[Severity]int{
+			SeverityLow:    0,
+			SeverityMedium: 1,
+			SeverityHigh:   2,
+		},
+	}
+	for lang, prefixes := range defaultPrefixes {
+		c.setPrefixes(lang, prefixes)
+	}
+	for lang, folds := range defaultFolds {
+		for from, to := range folds {
+			c.setFold(lang, from, to)
+		}
+	}
+	for _, opt := range opts {
+		opt(c)
 	}
+	return c
 }
 
-func (c *Censor) AddWord(word string, lang string) {
-	stemmer, ok := c.stemmers[lang]
-	if !ok {
-		stemmer = ugustemmer.NewSnowballStemmer(lang)
-		c.stemmers[lang] = stemmer
-	}
+func (c *Censor) AddWord(word string, lang string, opts ...EntryOption) {
+	stemmer := c.stemmer(lang)
 	if _, ok := c.dicts[lang]; !ok {
 		c.dicts[lang] = trie.NewTrie()
+		c.entries[lang] = make(map[string]*entry)
 	}
+
+	word = c.normalizeWord(word, lang)
+
+	e := &entry{
+		word:           word,
+		severity:       SeverityMedium,
+		prefixMatching: c.prefixMatching,
+	}
+	for _, opt := range opts {
+		opt(e)
+	}
+	if e.prefixMatching {
+		c.prefixLangs[lang] = true
+	}
+
+	if c.skeletonMinLen > 0 {
+		c.addSkeleton(word, lang, e)
+	}
+
+	if c.expandParadigms {
+		e.forms = paradigm(word, lang, e.fleetingVowel)
+		for _, form := range e.forms {
+			c.dicts[lang].Insert(form)
+			c.entries[lang][form] = e
+			if c.skeletonMinLen > 0 {
+				c.addSkeleton(form, lang, e)
+			}
+		}
+		return
+	}
+
 	if stemmer != nil {
 		word = stemmer.Stem(word)
 	}
 	c.dicts[lang].Insert(word)
+	c.entries[lang][word] = e
+	if c.skeletonMinLen > 0 {
+		c.addSkeleton(word, lang, e)
+	}
+}
+
+// Forms returns the inflected forms the word was expanded into when it was
+// added, or nil if the word is not in the dictionary or was added without
+// WithParadigmExpansion.
+func (c *Censor) Forms(word string, lang string) []string {
+	e, ok := c.entries[lang][c.normalizeWord(word, lang)]
+	if !ok || e.forms == nil {
+		return nil
+	}
+	return append([]string(nil), e.forms...)
+}
+
+func (c *Censor) stemmer(lang string) Stemmer {
+	stemmer, ok := c.stemmers[lang]
+	if !ok {
+		// NewSnowballStemmer returns a nil pointer for unsupported
+		// languages, which must not end up as a non-nil interface.
+		if s := ugustemmer.NewSnowballStemmer(lang); s != nil {
+			stemmer = s
+		}
+		c.stemmers[lang] = stemmer
+	}
+	return stemmer
 }
 
-func (c *Censor) AddWords(words []string, lang string) {
+func (c *Censor) AddWords(words []string, lang string, opts ...EntryOption) {
 	for _, word := range words {
-		c.AddWord(word, lang)
+		c.AddWord(word, lang, opts...)
+	}
+}
+
+// AllowWord adds a word that is never censored, even if it contains or
+// resembles a dictionary word: "тигр" with "игр" matched inside words.
+// Matches are dropped when the whole word they are found in is allowed,
+// or, with WithWordSplitting, the camelCase part they are found in. Like
+// dictionary words, allowed words cover their inflected forms: "тигры" is
+// allowed along with "тигр".
+func (c *Censor) AllowWord(word string, lang string) {
+	if c.allowed[lang] == nil {
+		c.allowed[lang] = make(map[string]bool)
+	}
+	word = c.normalizeWord(word, lang)
+
+	if c.expandParadigms {
+		for _, form := range paradigm(word, lang, false) {
+			c.allowed[lang][form] = true
+		}
+		return
+	}
+
+	c.allowed[lang][word] = true
+	if stemmer := c.stemmer(lang); stemmer != nil {
+		c.allowed[lang][stemmer.Stem(word)] = true
+	}
+}
+
+// isAllowed reports whether the normalized word is an allowed word or, like
+// in lookup, one of its forms.
+func (c *Censor) isAllowed(word string, lang string) bool {
+	allowed := c.allowed[lang]
+	if allowed[word] {
+		return true
+	}
+	if c.expandParadigms {
+		return false
+	}
+	if stemmer := c.stemmers[lang]; stemmer != nil {
+		return allowed[stemmer.Stem(word)]
+	}
+	return false
+}
+
+func (c *Censor) AllowWords(words []string, lang string) {
+	for _, word := range words {
+		c.AllowWord(word, lang)
 	}
 }
 
@@ -163,73 +379,312 @@ type PossibleBadWordBounds struct {
 	End     int
 }
 
-func (c *Censor) twoPassCensorText(text string, lang string) (string, bool) {
-	var (
-		result   strings.Builder
-		censored bool
+// Match describes a bad word found in the text.
+type Match struct {
+	// Start and End are rune offsets of the masked span in the text.
+	Start int
+	End   int
+	// ByteStart and ByteEnd are the offsets of the span in bytes of the
+	// UTF-8 text, UTF16Start and UTF16End in UTF-16 code units, as
+	// JavaScript, Java and Swift strings are indexed.
+	ByteStart  int
+	ByteEnd    int
+	UTF16Start int
+	UTF16End   int
+	// Word is the lowercased word as it was matched, letters only.
+	Word string
+	// Entry is the dictionary entry the word matched.
+	Entry string
+	// Prefix is the prefix skipped before the entry by prefix matching.
+	Prefix string
+	// Distance is the edit distance between the word and the entry for
+	// fuzzy matches, zero otherwise.
+	Distance int
+	Severity Severity
+	// Category is the category of the entry, see EntryCategory.
+	Category string
+	Kind     MatchKind
+	// Confidence tells how likely the word is meant as the entry, from 0
+	// to 1: exact matches are certain, looser ones like fuzzy or skeleton
+	// matches are less so.
+	Confidence float64
+}
 
-		possibleBadWordStarts []int
-	)
+// MatchKind tells how a word was matched.
+type MatchKind int
+
+const (
+	// MatchExact is a word found in the dictionary directly or by its stem.
+	MatchExact MatchKind = iota
+	// MatchPrefixed is a dictionary word behind a prefix, see WithPrefixMatching.
+	MatchPrefixed
+	// MatchFuzzy is a misspelled dictionary word, see WithFuzzyMatching.
+	MatchFuzzy
+	// MatchTranslit is a transliterated dictionary word, see WithTransliteration.
+	MatchTranslit
+	// MatchLayout is a dictionary word typed with the wrong keyboard layout,
+	// see WithLayoutMatching.
+	MatchLayout
+	// MatchSpaced is a dictionary word spelled letter by letter: "и г р а".
+	MatchSpaced
+	// MatchSelfCensored is a dictionary word with letters hidden behind
+	// wildcards by the author: "и*ра", see WithWildcards.
+	MatchSelfCensored
+	// MatchSkeleton is a dictionary word with its vowels dropped or hidden:
+	// "блк", "бл*к", see WithSkeletonMatching.
+	MatchSkeleton
+	// MatchReversed is a dictionary word spelled backwards: "арги", see
+	// WithReversedMatching.
+	MatchReversed
+	// MatchCompound is a dictionary word inside a longer word: a part of a
+	// camelCase word or hashtag, see WithWordSplitting and WithInfixMatching.
+	MatchCompound
+)
 
-	result.Grow(len(text))
+// Obfuscated reports whether matches of the kind are words the author
+// disguised on purpose rather than wrote plainly or misspelled.
+func (k MatchKind) Obfuscated() bool {
+	switch k {
+	case MatchExact, MatchPrefixed, MatchFuzzy, MatchCompound:
+		return false
+	}
+	return true
+}
 
-	stemmer := c.stemmers[lang]
+// crossScript reports whether matches of the kind are written in another
+// script than the dictionary.
+func (k MatchKind) crossScript() bool {
+	return k == MatchTranslit || k == MatchLayout
+}
 
+// FindMatches returns the bad words found in the text, ordered by position.
+func (c *Censor) FindMatches(text string, lang string) []Match {
+	return c.findMatches([]rune(text), lang)
+}
+
+func (c *Censor) twoPassCensorText(text string, lang string) (string, bool) {
 	runes := []rune(text)
 
+	matches := c.findMatches(runes, lang)
+	if len(matches) == 0 && !c.stripInvisible {
+		return text, false
+	}
+
+	return c.maskMatches(runes, matches), len(matches) > 0
+}
+
+func (c *Censor) findMatches(runes []rune, lang string) []Match {
+	if c.dicts[lang] == nil {
+		return nil
+	}
+
+	text := c.normalize(runes, lang)
+
+	matches := c.findNormalizedMatches(text, lang)
+	for i, m := range matches {
+		matches[i] = text.original(m)
+	}
+	setOffsets(runes, matches)
+	return matches
+}
+
+func (c *Censor) findNormalizedMatches(text normalized, lang string) []Match {
+	runes := text.runes
+
 	// first pass
 	// find all possible bad word starts
 
-	possibleBadWordStarts = c.findPossibleBadWordStarts(runes, lang)
+	possibleBadWordStarts, prefixedStarts := c.findPossibleBadWordStarts(runes, lang)
 
 	// second pass
-	// check all possible bad word starts and censor them
+	// check all possible bad word starts
 
 	wordBounds := c.findPossibleBadWordBounds(runes, possibleBadWordStarts, lang)
 
-	if len(wordBounds) == 0 {
-		return text, false
+	var matches []Match
+	for _, wb := range wordBounds {
+		// Check if the word is a bad word, either directly or via the stemmer
+		if e, ok := c.lookup(wb.Word, wb.BadPart, lang); ok {
+			matches = append(matches, Match{
+				Start:      wb.Start,
+				End:        wb.End,
+				Word:       wb.Word,
+				Entry:      e.word,
+				Severity:   e.severity,
+				Category:   e.category,
+				Confidence: 1,
+			})
+		}
 	}
 
-	// check all possible bad words, censor them and write result
+	matches = append(matches, c.findSpacedMatches(runes, lang)...)
+
+	matches = append(matches, c.findPrefixedMatches(runes, prefixedStarts, lang)...)
 
-	for i, wb := range wordBounds {
-		// Determine the previous end index or start from 0
-		prevEnd := 0
-		if i > 0 {
-			prevEnd = wordBounds[i-1].End
+	if c.reversed {
+		matches = append(matches, c.findReversedMatches(runes, lang)...)
+	}
+
+	if c.transliteration {
+		matches = mergeMatches(matches)
+		matches = append(matches, c.findTranslitMatches(text, lang, matches)...)
+	}
+
+	if c.layoutMatching {
+		matches = mergeMatches(matches)
+		matches = append(matches, c.findLayoutMatches(runes, lang, matches)...)
+	}
+
+	if c.maxWildcards > 0 {
+		matches = append(matches, c.findWildcardMatches(runes, lang)...)
+	}
+
+	if c.wordSplitting {
+		matches = mergeMatches(matches)
+		matches = append(matches, c.findSplitMatches(text, lang, matches)...)
+	}
+
+	if c.infixMinLen > 0 {
+		matches = mergeMatches(matches)
+		matches = append(matches, c.findInfixMatches(text, lang, matches)...)
+	}
+
+	if c.skeletonMinLen > 0 {
+		matches = mergeMatches(matches)
+		matches = append(matches, c.findSkeletonMatches(runes, lang, matches)...)
+	}
+
+	if c.fuzzyMinLen > 0 {
+		matches = mergeMatches(matches)
+		matches = append(matches, c.findFuzzyMatches(runes, lang, matches)...)
+	}
+
+	matches = mergeMatches(matches)
+	if len(c.allowed[lang]) > 0 {
+		matches = c.dropAllowed(text, lang, matches)
+	}
+	return matches
+}
+
+// lookup checks a word found by findPossibleBadWordBounds against the
+// dictionary and returns the entry it matched.
+func (c *Censor) lookup(word string, badPart string, lang string) (*entry, bool) {
+	dict := c.dicts[lang]
+
+	if word == badPart || dict.Search(word) {
+		return c.entries[lang][word], true
+	}
+
+	// expanded entries are exact forms, and stems would match unrelated
+	// words sharing them again
+	if stemmer := c.stemmers[lang]; stemmer != nil && !c.expandParadigms {
+		if stem := stemmer.Stem(word); dict.Search(stem) {
+			return c.entries[lang][stem], true
 		}
-		// Append the text before the current word
-		result.WriteString(string(runes[prevEnd:wb.Start]))
+	}
+	return nil, false
+}
 
-		// Check if the word is a bad word, either directly or via the stemmer
-		isBadWord := wb.Word == wb.BadPart ||
-			(stemmer != nil && c.dicts[lang].Search(stemmer.Stem(wb.Word)))
+// mergeMatches sorts matches by position and drops the ones overlapping
+// an earlier (or, at the same position, longer) match.
+func mergeMatches(matches []Match) []Match {
+	sort.SliceStable(matches, func(i, j int) bool {
+		if matches[i].Start != matches[j].Start {
+			return matches[i].Start < matches[j].Start
+		}
+		return matches[i].End > matches[j].End
+	})
 
-		if isBadWord {
-			// Replace the bad word with asterisks
-			result.WriteString(strings.Repeat("*", wb.End-wb.Start))
-			censored = true
+	merged := matches[:0]
+	for _, m := range matches {
+		if len(merged) > 0 && m.Start < merged[len(merged)-1].End {
 			continue
 		}
+		merged = append(merged, m)
+	}
+	return merged
+}
+
+// MaskStyle tells how the letters of a matched word are masked.
+type MaskStyle int
+
+const (
+	// MaskFull replaces every letter with an asterisk: "****".
+	MaskFull MaskStyle = iota
+	// MaskKeepFirst keeps the first letter: "и***".
+	MaskKeepFirst
+	// MaskKeepEdges keeps the first and the last letter: "и**а".
+	MaskKeepEdges
+)
+
+// maskMatches replaces every visible rune of the matched spans with an
+// asterisk. Matches must be sorted and must not overlap.
+func (c *Censor) maskMatches(runes []rune, matches []Match) string {
+	return c.maskMatchesStyle(runes, matches, MaskFull)
+}
+
+// maskMatchesStyle masks the matched spans in the style given.
+func (c *Censor) maskMatchesStyle(runes []rune, matches []Match, style MaskStyle) string {
+	var result strings.Builder
+	result.Grow(len(runes))
 
-		// Append the current word as it is not censored
-		result.WriteString(string(runes[wb.Start:wb.End]))
+	prevEnd := 0
+	for _, m := range matches {
+		// Append the text before the current word
+		c.writeRunes(&result, runes[prevEnd:m.Start])
+		// Replace the bad word with asterisks
+		writeMask(&result, runes[m.Start:m.End], style)
+		prevEnd = m.End
 	}
 
 	// write the rest of the text
-	result.WriteString(string(runes[wordBounds[len(wordBounds)-1].End:]))
+	c.writeRunes(&result, runes[prevEnd:])
 
-	return result.String(), censored
+	return result.String()
+}
+
+// writeMask writes the mask of a matched span, one asterisk for every
+// grapheme cluster, dropping invisible characters.
+func writeMask(result *strings.Builder, span []rune, style MaskStyle) {
+	clusters := visibleGraphemes(span)
+	n := len(clusters)
+	for i, cluster := range clusters {
+		keep := style == MaskKeepFirst && i == 0 ||
+			style == MaskKeepEdges && n > 2 && (i == 0 || i == n-1)
+		if keep {
+			result.WriteString(string(cluster))
+		} else {
+			result.WriteByte('*')
+		}
+	}
 }
 
-func (c *Censor) findPossibleBadWordStarts(runes []rune, lang string) []int {
+// writeRunes writes runes to the result, dropping invisible characters if
+// the Censor was created with WithStripInvisible.
+func (c *Censor) writeRunes(result *strings.Builder, runes []rune) {
+	if !c.stripInvisible {
+		result.WriteString(string(runes))
+		return
+	}
+	for _, ch := range runes {
+		if !isInvisible(ch) {
+			result.WriteRune(ch)
+		}
+	}
+}
+
+// findPossibleBadWordStarts returns the starts of the words that may be bad
+// words and, if the language has entries with prefix matching, the starts
+// of the words beginning with a prefix of the language's prefix table.
+func (c *Censor) findPossibleBadWordStarts(runes []rune, lang string) ([]int, []int) {
 	var (
 		possibleBadWordStarts []int
+		prefixedStarts        []int
 		newWord               bool
 
 		cursor   = c.dicts[lang].Cursor()
 		lenRunes = len(runes)
+		prefixed = c.prefixLangs[lang]
 	)
 
 	for i, ch := range runes {
@@ -239,6 +694,11 @@ func (c *Censor) findPossibleBadWordStarts(runes []rune, lang string) []int {
 		}
 
 		if newWord {
+			// the word after a prefix is checked in the second pass
+			if prefixed && isLetter && c.hasPrefixAt(runes, i, lang) {
+				prefixedStarts = append(prefixedStarts, i)
+			}
+
 			cursor.Reset()
 
 			// check first letter
@@ -263,7 +723,7 @@ func (c *Censor) findPossibleBadWordStarts(runes []rune, lang string) []int {
 			}
 		}
 	}
-	return possibleBadWordStarts
+	return possibleBadWordStarts, prefixedStarts
 }
 
 func (c *Censor) findPossibleBadWordBounds(runes []rune, starts []int, lang string) []PossibleBadWordBounds {
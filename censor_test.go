@@ -77,17 +77,16 @@ func TestCensor_CensorText(t *testing.T) {
 	})
 
 	t.Run("non-standart punctuation", func(t *testing.T) {
-		// TODO: full word censoring
-		f("и.г.р.а...", "*****.а...", true)
-		f("_И_Г_Р_А_", "_*****_А_", true)
+		f("и.г.р.а...", "*******...", true)
+		f("_И_Г_Р_А_", "_*******_", true)
 		f("Это иг....ра!", "Это ********!", true)
 		f("Эта и..гр...а лучшая", "Эта *****...а лучшая", true)
-		f("И.Г.Р.А, а я*бл*о*к*о потом.", "*****.А, а *********о потом.", true)
-		f("Это та самая и г р а!", "Это та самая ***** а!", true)
+		f("И.Г.Р.А, а я*бл*о*к*о потом.", "*******, а *********о потом.", true)
+		f("Это та самая и г р а!", "Это та самая *******!", true)
 	})
 
 	t.Run("mixed punctuation", func(t *testing.T) {
-		f("самая и г р а как игр, ат", "самая ***** а как ***, ат", true)
+		f("самая и г р а как игр, ат", "самая ******* как ***, ат", true)
 	})
 
 	t.Run("no false positives", func(t *testing.T) {
@@ -131,6 +130,70 @@ func BenchmarkCensorText(b *testing.B) {
 	}
 }
 
+func TestCensor_CensorText_ParadigmExpansion(t *testing.T) {
+	c := NewCensor(WithParadigmExpansion())
+
+	c.AddWords([]string{"игра", "игрок", "яблоко"}, "ru")
+	c.AddWord("кусок", "ru", FleetingVowel())
+	c.AddWords([]string{"game"}, "en")
+
+	f := func(text string, lang string, expected string, expectedCensored bool) {
+		t.Helper()
+
+		got, gotCensored := c.CensorText(text, lang)
+		if got != expected || gotCensored != expectedCensored {
+			t.Errorf("\nCensorText(%q, %q)\n\tgot : %s, %v\n\twant: %s, %v", text, lang, got, gotCensored, expected, expectedCensored)
+		}
+	}
+
+	t.Run("inflected forms", func(t *testing.T) {
+		f("Два куска.", "ru", "Два *****.", true)
+		f("Все заваляно играми.", "ru", "Все заваляно ******.", true)
+		f("Не верь игрокам.", "ru", "Не верь *******.", true)
+		f("Торгует яблоками.", "ru", "Торгует ********.", true)
+		f("no more gaming, gamers", "en", "no more ******, ******", true)
+	})
+
+	t.Run("no false positives", func(t *testing.T) {
+		f("играция", "ru", "играция", false)
+		f("игроковый", "ru", "игроковый", false)
+		f("и грация", "ru", "и грация", false)
+		f("gamma", "en", "gamma", false)
+	})
+}
+
+func TestCensor_CensorText_NoStemmer(t *testing.T) {
+	c := NewCensor()
+	c.AddWord("game", "en")
+
+	got, censored := c.CensorText("a game", "en")
+	if got != "a ****" || !censored {
+		t.Errorf("CensorText(%q, \"en\") = %q, %v; want %q, true", "a game", got, censored, "a ****")
+	}
+}
+
+func TestCensor_Forms(t *testing.T) {
+	c := NewCensor(WithParadigmExpansion())
+	c.AddWord("игра", "ru")
+
+	forms := c.Forms("Игра", "ru")
+	if len(forms) == 0 || forms[0] != "игра" {
+		t.Errorf("Forms(%q) = %v; want forms starting with %q", "Игра", forms, "игра")
+	}
+	if got := c.Forms("игрой", "ru"); len(got) != len(forms) {
+		t.Errorf("Forms(%q) = %v; want %v", "игрой", got, forms)
+	}
+	if got := c.Forms("яблоко", "ru"); got != nil {
+		t.Errorf("Forms(%q) = %v; want nil", "яблоко", got)
+	}
+
+	stemmed := NewCensor()
+	stemmed.AddWord("игра", "ru")
+	if got := stemmed.Forms("игра", "ru"); got != nil {
+		t.Errorf("Forms(%q) without expansion = %v; want nil", "игра", got)
+	}
+}
+
 func TestCensor_findPossibleBadWordStarts(t *testing.T) {
 	c := NewCensor()
 
@@ -140,7 +203,7 @@ func TestCensor_findPossibleBadWordStarts(t *testing.T) {
 	f := func(text string, expected []int) {
 		t.Helper()
 
-		got := c.findPossibleBadWordStarts([]rune(text), "ru")
+		got, _ := c.findPossibleBadWordStarts([]rune(text), "ru")
 		if len(got) != len(expected) {
 			t.Errorf("\nfindPossibleBadWordStarts(%q, \"ru\")\n\tgot : %v\n\twant: %v", text, got, expected)
 			return
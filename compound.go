@@ -0,0 +1,180 @@
+package ugucensor
+
+import (
+	"unicode"
+	"unicode/utf8"
+)
+
+// wordParts returns the letter tokens of the text, split at camelCase
+// boundaries if the Censor was created with WithWordSplitting.
+func (c *Censor) wordParts(text normalized) []token {
+	tokens := letterTokens(text.runes)
+	if !c.wordSplitting {
+		return tokens
+	}
+
+	var parts []token
+	for _, tok := range tokens {
+		parts = append(parts, camelCaseParts(tok, text.upper)...)
+	}
+	return parts
+}
+
+// camelCaseParts splits the token before an uppercase letter following a
+// lowercase one ("Супер|Игра") and before the last letter of an uppercase
+// run followed by a lowercase one ("XML|Parser").
+func camelCaseParts(tok token, upper []bool) []token {
+	var parts []token
+	start := tok.start
+	for i := tok.start + 1; i < tok.end; i++ {
+		lowerToUpper := upper[i] && !upper[i-1]
+		upperRunEnd := upper[i] && upper[i-1] && i+1 < tok.end && !upper[i+1]
+		if lowerToUpper || upperRunEnd {
+			parts = append(parts, token{start, i})
+			start = i
+		}
+	}
+	return append(parts, token{start, tok.end})
+}
+
+// findSplitMatches checks the camelCase parts of the words not matched yet
+// as words of their own.
+func (c *Censor) findSplitMatches(text normalized, lang string, found []Match) []Match {
+	var matches []Match
+	for _, part := range uncoveredTokens(c.wordParts(text), found) {
+		if m, ok := c.matchWord(text.runes[part.start:part.end], lang); ok {
+			m.Start, m.End = part.start, part.end
+			m.Kind = MatchCompound
+			matches = append(matches, m)
+		}
+	}
+	return matches
+}
+
+// findInfixMatches finds dictionary words of at least infixMinLen letters
+// anywhere inside the words not matched yet. At every position the longest
+// word checked with the stem path wins unless the next word starts inside
+// it, so "суперигра" masks "игра" rather than the stem "игр", and
+// "играяблоко" masks "игра" and "яблоко" rather than "играя".
+func (c *Censor) findInfixMatches(text normalized, lang string, found []Match) []Match {
+	var matches []Match
+	for _, part := range uncoveredTokens(c.wordParts(text), found) {
+		for pos := part.start; pos < part.end; {
+			m, ok := c.matchInfix(text.runes[pos:part.end], lang)
+			if !ok {
+				pos++
+				continue
+			}
+			m.Start, m.End = pos, pos+m.End
+			m.Kind = MatchCompound
+			matches = append(matches, m)
+			pos = m.End
+		}
+	}
+	return matches
+}
+
+// matchInfix returns the dictionary word at the start of the letters, with
+// its end relative to them.
+func (c *Censor) matchInfix(letters []rune, lang string) (Match, bool) {
+	candidates := c.infixCandidates(letters, lang)
+	for i, m := range candidates {
+		nextStartsInside := false
+		for _, shorter := range candidates[i+1:] {
+			if len(c.infixCandidates(letters[shorter.End:], lang)) > 0 {
+				nextStartsInside = true
+				break
+			}
+		}
+		if !nextStartsInside {
+			return m, true
+		}
+	}
+	return Match{}, false
+}
+
+// maxInfixWordLen caps the letters checked for a word at every position of
+// infix matching, so long tokens like hashtags or URLs take linear time.
+const maxInfixWordLen = 48
+
+// maxInfixEnding is the number of letters an infix word may run past the
+// dictionary key it starts with, like the ending after a stem: "игр|а".
+const maxInfixEnding = 4
+
+// infixCandidates returns the dictionary words at the start of the letters,
+// longest first. The trie is walked once to find the keys starting the
+// letters; a word may end at a key, a few letters past it, or at the end
+// of the token.
+func (c *Censor) infixCandidates(letters []rune, lang string) []Match {
+	tokenEnd := len(letters)
+	if len(letters) > maxInfixWordLen {
+		letters = letters[:maxInfixWordLen]
+		tokenEnd = -1
+	}
+
+	cursor := c.dicts[lang].Cursor()
+	ends := make(map[int]bool)
+	for i, ch := range letters {
+		hasPrefix, isEnd := cursor.Advance(ch)
+		if !hasPrefix {
+			break
+		}
+		if isEnd {
+			for end := i + 1; end <= i+1+maxInfixEnding && end <= len(letters); end++ {
+				ends[end] = true
+			}
+		}
+	}
+	if len(ends) == 0 {
+		return nil
+	}
+	if tokenEnd > 0 {
+		ends[tokenEnd] = true
+	}
+
+	var candidates []Match
+	for end := len(letters); end > 0; end-- {
+		if !ends[end] {
+			continue
+		}
+		m, ok := c.matchWord(letters[:end], lang)
+		if ok && utf8.RuneCountInString(m.Entry) >= c.infixMinLen {
+			candidates = append(candidates, m)
+		}
+	}
+	return candidates
+}
+
+// dropAllowed drops the matches found in allowed words, see AllowWord.
+func (c *Censor) dropAllowed(text normalized, lang string, matches []Match) []Match {
+	runes := text.runes
+
+	kept := matches[:0]
+	for _, m := range matches {
+		start, end := m.Start, m.End
+		for start > 0 && unicode.IsLetter(runes[start-1]) {
+			start--
+		}
+		for end < len(runes) && unicode.IsLetter(runes[end]) {
+			end++
+		}
+		if c.isAllowed(string(runes[start:end]), lang) {
+			continue
+		}
+
+		if c.wordSplitting {
+			inAllowedPart := false
+			for _, part := range camelCaseParts(token{start, end}, text.upper) {
+				if part.start <= m.Start && m.Start < part.end {
+					inAllowedPart = c.isAllowed(string(runes[part.start:part.end]), lang)
+					break
+				}
+			}
+			if inAllowedPart {
+				continue
+			}
+		}
+		kept = append(kept, m)
+	}
+	return kept
+}
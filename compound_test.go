@@ -0,0 +1,136 @@
+package ugucensor
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCensor_CensorText_WordSplitting(t *testing.T) {
+	c := NewCensor(WithWordSplitting())
+	c.AddWord("игра", "ru")
+	c.AddWord("game", "en")
+
+	f := func(text string, expected string, expectedCensored bool) {
+		t.Helper()
+
+		lang := "ru"
+		if isLatin([]rune(text)) {
+			lang = "en"
+		}
+		got, gotCensored := c.CensorText(text, lang)
+		if got != expected || gotCensored != expectedCensored {
+			t.Errorf("CensorText(%q) = %q, %v; want %q, %v", text, got, gotCensored, expected, expectedCensored)
+		}
+	}
+
+	t.Run("camelCase", func(t *testing.T) {
+		f("СуперИграТоп", "Супер****Топ", true)
+		f("суперИгры", "супер****", true)
+		f("superGameTop", "super****Top", true)
+	})
+
+	t.Run("uppercase runs", func(t *testing.T) {
+		f("GAMEOver", "****Over", true)
+		f("ИГРА", "****", true)
+	})
+
+	t.Run("hashtags and snake case", func(t *testing.T) {
+		f("#супер_игра", "#супер_****", true)
+	})
+
+	t.Run("without case changes", func(t *testing.T) {
+		f("суперигра", "суперигра", false)
+	})
+}
+
+func TestCensor_CensorText_Infix(t *testing.T) {
+	c := NewCensor(WithInfixMatching(3))
+	c.AddWords([]string{"игра", "яблоко", "ад"}, "ru")
+
+	f := func(text string, expected string, expectedCensored bool) {
+		t.Helper()
+
+		got, gotCensored := c.CensorText(text, "ru")
+		if got != expected || gotCensored != expectedCensored {
+			t.Errorf("CensorText(%q) = %q, %v; want %q, %v", text, got, gotCensored, expected, expectedCensored)
+		}
+	}
+
+	t.Run("inside words", func(t *testing.T) {
+		f("#суперигра", "#супер****", true)
+		f("мояигратоп", "моя****топ", true)
+		f("суперигры", "супер****", true)
+		f("игрояблоко", "**********", true)
+	})
+
+	t.Run("several words", func(t *testing.T) {
+		f("играяблоко", "**********", true)
+		f("играяблоки", "**********", true)
+	})
+
+	t.Run("short entries", func(t *testing.T) {
+		f("шоколад", "шоколад", false)
+		f("ад", "**", true)
+	})
+
+	t.Run("clean words", func(t *testing.T) {
+		f("программа", "программа", false)
+	})
+}
+
+func TestCensor_AllowWord(t *testing.T) {
+	c := NewCensor(WithInfixMatching(3), WithWordSplitting())
+	c.AddWord("игра", "ru")
+	c.AllowWords([]string{"тигр", "Пиигра"}, "ru")
+
+	f := func(text string, expected string, expectedCensored bool) {
+		t.Helper()
+
+		got, gotCensored := c.CensorText(text, "ru")
+		if got != expected || gotCensored != expectedCensored {
+			t.Errorf("CensorText(%q) = %q, %v; want %q, %v", text, got, gotCensored, expected, expectedCensored)
+		}
+	}
+
+	f("тигр", "тигр", false)
+	f("ТИГР", "ТИГР", false)
+	f("тигры", "тигры", false)
+	f("ТИГРОВ", "ТИГРОВ", false)
+	f("пиигра", "пиигра", false)
+	f("СуперТигр", "СуперТигр", false)
+	f("тигр и игра", "тигр и ****", true)
+
+	t.Run("paradigm expansion", func(t *testing.T) {
+		c := NewCensor(WithInfixMatching(3), WithParadigmExpansion())
+		c.AddWord("игра", "ru")
+		c.AllowWord("тигр", "ru")
+
+		for _, text := range []string{"тигр", "тиграми", "тигров"} {
+			if got, gotCensored := c.CensorText(text, "ru"); gotCensored {
+				t.Errorf("CensorText(%q) = %q, %v; want it unchanged", text, got, gotCensored)
+			}
+		}
+	})
+}
+
+func TestCensor_CensorText_InfixLongToken(t *testing.T) {
+	c := NewCensor(WithInfixMatching(4))
+	c.AddWords([]string{"игра", "игрок"}, "ru")
+
+	text := strings.Repeat("игр", 2000) + "игра"
+	got, gotCensored := c.CensorText(text, "ru")
+	if !gotCensored || !strings.HasSuffix(got, "****") {
+		t.Errorf("CensorText of a long token = %q..., %v; want the word at its end masked", string([]rune(got)[:12]), gotCensored)
+	}
+}
+
+func BenchmarkCensorText_InfixLongToken(b *testing.B) {
+	c := NewCensor(WithInfixMatching(4))
+	c.AddWords([]string{"игра", "игрок"}, "ru")
+	text := strings.Repeat("игр", 2000) + "игра"
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = c.CensorText(text, "ru")
+	}
+}
@@ -0,0 +1,200 @@
+package ugucensor
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// CSVColumn selects a column of a CSV file by its name in the header row,
+// or by its zero-based index if Name is empty.
+type CSVColumn struct {
+	Name  string
+	Index int
+}
+
+// ColumnName selects a column by its name in the header row.
+func ColumnName(name string) CSVColumn {
+	return CSVColumn{Name: name}
+}
+
+// ColumnIndex selects a column by its zero-based index.
+func ColumnIndex(index int) CSVColumn {
+	return CSVColumn{Index: index}
+}
+
+// CSVOptions configures CensorCSV.
+type CSVOptions struct {
+	// Columns are the columns to censor.
+	Columns []CSVColumn
+	// Lang is the language of the cells, or "auto" to detect it.
+	Lang string
+	// LangColumn, if set, is a column holding the language of each row;
+	// rows where it is empty use Lang.
+	LangColumn *CSVColumn
+	// Comma is the field delimiter, ',' if zero; '\t' reads TSV.
+	Comma rune
+	// Header marks the first row as the header. It is needed to select
+	// columns by name.
+	Header bool
+	// SummaryColumn, if not empty, adds a column with this name listing
+	// the bad words found in each row, like "title:игра;body:игра,игры".
+	// Shorter rows are padded to the width of the header, or of the first
+	// row without a header, to keep it in place.
+	SummaryColumn string
+}
+
+// CensorCSV censors the selected columns of the CSV records read from r
+// with Censor and writes them to w, one record at a time. Quoted fields
+// may span several lines; the output is quoted as needed to stay valid.
+// It returns the number of rows that had bad words.
+func (c *Censor) CensorCSV(r io.Reader, w io.Writer, opts CSVOptions) (int, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	cw := csv.NewWriter(w)
+	if opts.Comma != 0 {
+		cr.Comma = opts.Comma
+		cw.Comma = opts.Comma
+	}
+
+	var (
+		columns   []int
+		labels    map[int]string
+		langIndex = -1
+		rows      int
+		// width is the number of fields of the header, or of the first
+		// record without a header
+		width int
+	)
+	resolve := func(header []string) error {
+		labels = make(map[int]string)
+		for _, col := range opts.Columns {
+			i, err := csvColumnIndex(col, header)
+			if err != nil {
+				return err
+			}
+			columns = append(columns, i)
+			labels[i] = strconv.Itoa(i)
+			if i < len(header) && header[i] != "" {
+				labels[i] = header[i]
+			}
+		}
+		if opts.LangColumn != nil {
+			i, err := csvColumnIndex(*opts.LangColumn, header)
+			if err != nil {
+				return err
+			}
+			langIndex = i
+		}
+		return nil
+	}
+
+	if opts.Header {
+		header, err := cr.Read()
+		if err == io.EOF {
+			return 0, nil
+		}
+		if err != nil {
+			return 0, fmt.Errorf("ugucensor: CSV header: %w", err)
+		}
+		if err := resolve(header); err != nil {
+			return 0, err
+		}
+		width = len(header)
+		if opts.SummaryColumn != "" {
+			header = append(header, opts.SummaryColumn)
+		}
+		if err := cw.Write(header); err != nil {
+			return 0, err
+		}
+	} else if err := resolve(nil); err != nil {
+		return 0, err
+	}
+
+	for {
+		record, err := cr.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return rows, fmt.Errorf("ugucensor: CSV: %w", err)
+		}
+
+		lang := opts.Lang
+		if langIndex >= 0 && langIndex < len(record) && record[langIndex] != "" {
+			lang = record[langIndex]
+		}
+
+		var summary []string
+		for _, i := range columns {
+			if i >= len(record) {
+				continue
+			}
+			runes := []rune(record[i])
+			matches := c.findLangMatches(runes, lang)
+			if len(matches) == 0 && !c.stripInvisible {
+				continue
+			}
+			record[i] = c.maskMatches(runes, matches)
+			if len(matches) > 0 {
+				summary = append(summary, labels[i]+":"+matchedEntries(matches))
+			}
+		}
+		if len(summary) > 0 {
+			rows++
+		}
+
+		if opts.SummaryColumn != "" {
+			// short rows are padded, so the summary stays in its column
+			if width == 0 {
+				width = len(record)
+			}
+			for len(record) < width {
+				record = append(record, "")
+			}
+			record = append(record, strings.Join(summary, ";"))
+		}
+		if err := cw.Write(record); err != nil {
+			return rows, err
+		}
+	}
+
+	cw.Flush()
+	return rows, cw.Error()
+}
+
+// csvColumnIndex returns the index of the column in the header.
+func csvColumnIndex(col CSVColumn, header []string) (int, error) {
+	if col.Name == "" {
+		if col.Index < 0 {
+			return 0, fmt.Errorf("ugucensor: negative CSV column index %d", col.Index)
+		}
+		return col.Index, nil
+	}
+	if header == nil {
+		return 0, errors.New("ugucensor: CSV columns selected by name need a header")
+	}
+	for i, name := range header {
+		if name == col.Name {
+			return i, nil
+		}
+	}
+	return 0, fmt.Errorf("ugucensor: no CSV column %q", col.Name)
+}
+
+// matchedEntries lists the dictionary entries of the matches, without
+// repeats, separated by commas.
+func matchedEntries(matches []Match) string {
+	seen := make(map[string]bool)
+	var entries []string
+	for _, m := range matches {
+		if !seen[m.Entry] {
+			seen[m.Entry] = true
+			entries = append(entries, m.Entry)
+		}
+	}
+	return strings.Join(entries, ",")
+}
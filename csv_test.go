@@ -0,0 +1,90 @@
+package ugucensor
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCensor_CensorCSV(t *testing.T) {
+	c := NewCensor()
+	c.AddWords([]string{"игра", "яблоко"}, "ru")
+	c.AddWords([]string{"game"}, "en")
+
+	f := func(src string, opts CSVOptions, expected string, expectedRows int) {
+		t.Helper()
+
+		var out strings.Builder
+		rows, err := c.CensorCSV(strings.NewReader(src), &out, opts)
+		if err != nil {
+			t.Fatalf("CensorCSV(%q) error: %v", src, err)
+		}
+		if got := out.String(); got != expected || rows != expectedRows {
+			t.Errorf("CensorCSV(%q) = %q, %d; want %q, %d", src, got, rows, expected, expectedRows)
+		}
+	}
+
+	t.Run("columns by name", func(t *testing.T) {
+		src := "id,title,body\nигра,игра,мир\n2,мир,яблоко\n"
+		f(src, CSVOptions{Columns: []CSVColumn{ColumnName("title"), ColumnName("body")}, Lang: "ru", Header: true},
+			"id,title,body\nигра,****,мир\n2,мир,******\n", 2)
+		f(src, CSVOptions{Columns: []CSVColumn{ColumnName("body")}, Lang: "ru", Header: true},
+			"id,title,body\nигра,игра,мир\n2,мир,******\n", 1)
+	})
+
+	t.Run("columns by index", func(t *testing.T) {
+		f("игра,игра\nигра,мир\n", CSVOptions{Columns: []CSVColumn{ColumnIndex(1)}, Lang: "ru"},
+			"игра,****\nигра,мир\n", 1)
+	})
+
+	t.Run("language column", func(t *testing.T) {
+		src := "lang,text\nru,игра\nen,game\n,game\n"
+		f(src, CSVOptions{Columns: []CSVColumn{ColumnName("text")}, LangColumn: &CSVColumn{Name: "lang"}, Lang: "ru", Header: true},
+			"lang,text\nru,****\nen,****\n,game\n", 2)
+	})
+
+	t.Run("summary column", func(t *testing.T) {
+		src := "title,body\nигра,игра и игры и яблоко\nмир,мир\n"
+		f(src, CSVOptions{Columns: []CSVColumn{ColumnName("title"), ColumnName("body")}, Lang: "ru", Header: true, SummaryColumn: "matches"},
+			"title,body,matches\n****,**** и **** и ******,\"title:игра;body:игра,яблоко\"\nмир,мир,\n", 1)
+		f("игра\n", CSVOptions{Columns: []CSVColumn{ColumnIndex(0)}, Lang: "ru", SummaryColumn: "matches"},
+			"****,0:игра\n", 1)
+	})
+
+	t.Run("quoted multiline fields", func(t *testing.T) {
+		src := "id,body\n1,\"мир,\nигра\"\n2,\"сказал \"\"игра\"\"\"\n"
+		f(src, CSVOptions{Columns: []CSVColumn{ColumnName("body")}, Lang: "ru", Header: true},
+			"id,body\n1,\"мир,\n****\"\n2,\"сказал \"\"****\"\"\"\n", 2)
+	})
+
+	t.Run("TSV", func(t *testing.T) {
+		f("a\tигра, яблоко\n", CSVOptions{Columns: []CSVColumn{ColumnIndex(1)}, Lang: "ru", Comma: '\t'},
+			"a\t****, ******\n", 1)
+	})
+
+	t.Run("short rows", func(t *testing.T) {
+		f("a,b\nигра\n", CSVOptions{Columns: []CSVColumn{ColumnName("b")}, Lang: "ru", Header: true},
+			"a,b\nигра\n", 0)
+		f("a,b\nигра\n", CSVOptions{Columns: []CSVColumn{ColumnName("a")}, Lang: "ru", Header: true, SummaryColumn: "m"},
+			"a,b,m\n****,,a:игра\n", 1)
+		f("игра,мир\nигра\n", CSVOptions{Columns: []CSVColumn{ColumnIndex(0)}, Lang: "ru", SummaryColumn: "m"},
+			"****,мир,0:игра\n****,,0:игра\n", 2)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		for _, opts := range []CSVOptions{
+			{Columns: []CSVColumn{ColumnName("body")}, Lang: "ru"},
+			{Columns: []CSVColumn{ColumnName("missing")}, Lang: "ru", Header: true},
+			{Columns: []CSVColumn{ColumnIndex(-1)}, Lang: "ru"},
+		} {
+			var out strings.Builder
+			if _, err := c.CensorCSV(strings.NewReader("body\nигра\n"), &out, opts); err == nil {
+				t.Errorf("CensorCSV(%+v) error = nil", opts)
+			}
+		}
+
+		var out strings.Builder
+		if _, err := c.CensorCSV(strings.NewReader("a\n\"игра\n"), &out, CSVOptions{Columns: []CSVColumn{ColumnIndex(0)}, Lang: "ru"}); err == nil {
+			t.Error("CensorCSV with an unclosed quote error = nil")
+		}
+	})
+}
@@ -0,0 +1,216 @@
+package ugucensor
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// Detection is the result of language detection.
+type Detection struct {
+	// Lang is the detected language, or "" if it is unknown.
+	Lang string
+	// Confidence is in range [0, 1].
+	Confidence float64
+}
+
+type languageProfile struct {
+	lang   string
+	script *unicode.RangeTable
+	// exclusive holds letters found only in this language among the
+	// languages sharing the script.
+	exclusive string
+	ranks     map[string]int
+}
+
+var languageProfiles = []*languageProfile{
+	newLanguageProfile("en", unicode.Latin, ""),
+	newLanguageProfile("ru", unicode.Cyrillic, "ыэъё"),
+	newLanguageProfile("uk", unicode.Cyrillic, "іїєґ"),
+}
+
+func newLanguageProfile(lang string, script *unicode.RangeTable, exclusive string) *languageProfile {
+	trigrams := strings.Split(trigramProfiles[lang], "|")
+	ranks := make(map[string]int, len(trigrams))
+	for i, trigram := range trigrams {
+		ranks[trigram] = i
+	}
+	return &languageProfile{
+		lang:      lang,
+		script:    script,
+		exclusive: exclusive,
+		ranks:     ranks,
+	}
+}
+
+// DetectLanguage detects the language of the text among the languages with
+// a bundled profile ("en", "ru", "uk").
+//
+// The script of the letters narrows down the candidates, then the character
+// trigrams of the text are compared to the profile of each candidate.
+// Confidence is lowered by letters of other scripts and by candidates
+// scoring close to the best one.
+func DetectLanguage(text string) Detection {
+	var (
+		letters int
+		scripts = make(map[*unicode.RangeTable]int)
+		chars   = make(map[rune]bool)
+	)
+	for _, ch := range text {
+		if !unicode.IsLetter(ch) {
+			continue
+		}
+		letters++
+		chars[unicode.ToLower(ch)] = true
+		for _, p := range languageProfiles {
+			if unicode.Is(p.script, ch) {
+				scripts[p.script]++
+				break
+			}
+		}
+	}
+	if letters == 0 {
+		return Detection{}
+	}
+
+	// ties are broken in the order of languageProfiles, so the result
+	// does not depend on the map order
+	var script *unicode.RangeTable
+	for _, p := range languageProfiles {
+		if n := scripts[p.script]; n > 0 && (script == nil || n > scripts[script]) {
+			script = p.script
+		}
+	}
+	if script == nil {
+		return Detection{}
+	}
+	scriptShare := float64(scripts[script]) / float64(letters)
+
+	trigrams := textTrigrams(text)
+
+	type score struct {
+		lang  string
+		value float64
+	}
+	var scores []score
+	for _, p := range languageProfiles {
+		if p.script != script {
+			continue
+		}
+		scores = append(scores, score{p.lang, p.score(trigrams, chars)})
+	}
+	sort.SliceStable(scores, func(i, j int) bool {
+		return scores[i].value > scores[j].value
+	})
+
+	best := scores[0]
+	if best.value == 0 {
+		return Detection{}
+	}
+	// a language nobody competes with still has to look like itself
+	second := unknownLanguageScore
+	if len(scores) > 1 && scores[1].value > second {
+		second = scores[1].value
+	}
+
+	return Detection{
+		Lang:       best.lang,
+		Confidence: scriptShare * best.value / (best.value + second),
+	}
+}
+
+// unknownLanguageScore is the score of an imaginary language without a
+// profile, so that text poorly matching the only candidate of its script
+// gets a low confidence.
+const unknownLanguageScore = 0.3
+
+// score returns the rank-weighted share of the text trigrams found in the
+// profile, boosted by letters exclusive to the language.
+func (p *languageProfile) score(trigrams map[string]int, chars map[rune]bool) float64 {
+	var total, hits float64
+	for trigram, n := range trigrams {
+		total += float64(n)
+		if rank, ok := p.ranks[trigram]; ok {
+			hits += float64(n) * (1 - float64(rank)/float64(len(p.ranks)))
+		}
+	}
+	if total == 0 {
+		return 0
+	}
+	score := hits / total
+
+	for _, other := range languageProfiles {
+		if other.script != p.script {
+			continue
+		}
+		for _, ch := range other.exclusive {
+			if !chars[ch] {
+				continue
+			}
+			if other == p {
+				score += 0.5
+			} else {
+				score /= 2
+			}
+			break
+		}
+	}
+	return score
+}
+
+func textTrigrams(text string) map[string]int {
+	trigrams := make(map[string]int)
+	for _, word := range strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && r != '\'' && r != '’'
+	}) {
+		runes := []rune(" " + word + " ")
+		for i := 0; i+3 <= len(runes); i++ {
+			trigrams[string(runes[i:i+3])]++
+		}
+	}
+	return trigrams
+}
+
+// CensorAuto detects the language of the text with DetectLanguage and
+// censors it with the dictionaries of that language.
+//
+// If the language is unknown, the confidence is below the minimum set with
+// WithMinConfidence or there are no dictionaries for the language, the text
+// is censored with every language of the fallback chain set with
+// WithFallbackLanguages (all languages with dictionaries by default).
+func (c *Censor) CensorAuto(text string) (string, bool, Detection) {
+	langs, detection := c.autoLanguages(text)
+	censored, ok := c.CensorMixed(text, langs...)
+	return censored, ok, detection
+}
+
+// autoLanguages returns the languages CensorAuto censors the text with.
+func (c *Censor) autoLanguages(text string) ([]string, Detection) {
+	detection := DetectLanguage(text)
+	if detection.Lang == "" || detection.Confidence < c.minConfidence || c.dicts[detection.Lang] == nil {
+		return c.fallbackChain(), detection
+	}
+	return []string{detection.Lang}, detection
+}
+
+// findLangMatches finds the matches in the language, or in the languages
+// CensorAuto would use if lang is "auto".
+func (c *Censor) findLangMatches(runes []rune, lang string) []Match {
+	if lang != "auto" {
+		return c.findMatches(runes, lang)
+	}
+	langs, _ := c.autoLanguages(string(runes))
+	return c.findMixedMatches(runes, langs)
+}
+
+func (c *Censor) fallbackChain() []string {
+	if c.fallbackLangs != nil {
+		return c.fallbackLangs
+	}
+	langs := make([]string, 0, len(c.dicts))
+	for lang := range c.dicts {
+		langs = append(langs, lang)
+	}
+	sort.Strings(langs)
+	return langs
+}
@@ -0,0 +1,106 @@
+package ugucensor
+
+import "testing"
+
+func TestDetectLanguage(t *testing.T) {
+	f := func(text string, expectedLang string, minConfidence float64) {
+		t.Helper()
+
+		got := DetectLanguage(text)
+		if got.Lang != expectedLang || got.Confidence < minConfidence {
+			t.Errorf("DetectLanguage(%q) = %+v; want %q with confidence >= %v", text, got, expectedLang, minConfidence)
+		}
+	}
+
+	t.Run("empty text", func(t *testing.T) {
+		f("", "", 0)
+		f("123, 456!", "", 0)
+	})
+
+	t.Run("russian", func(t *testing.T) {
+		f("Это та самая игра!", "ru", 0.5)
+		f("Мы играли в игры весь вечер", "ru", 0.5)
+		f("Мы грали до ночи", "ru", 0.5)
+	})
+
+	t.Run("ukrainian", func(t *testing.T) {
+		f("Ця гра має бути нашою", "uk", 0.5)
+		f("Ми грали в ігри весь вечір", "uk", 0.5)
+		f("Привіт", "uk", 0.5)
+	})
+
+	t.Run("english", func(t *testing.T) {
+		f("This is the game!", "en", 0.5)
+		f("Where are you going tonight?", "en", 0.5)
+	})
+
+	t.Run("low confidence", func(t *testing.T) {
+		f := func(text string) {
+			t.Helper()
+
+			if got := DetectLanguage(text); got.Confidence >= 0.5 {
+				t.Errorf("DetectLanguage(%q) = %+v; want confidence < 0.5", text, got)
+			}
+		}
+
+		f("Das ist das Spiel, und wir spielen gern")
+		f("Je ne sais pas ce que tu veux dire")
+		f("this игра is trash")
+	})
+
+	t.Run("script ties", func(t *testing.T) {
+		for _, text := range []string{"the мир", "мир the"} {
+			first := DetectLanguage(text)
+			for i := 0; i < 20; i++ {
+				if got := DetectLanguage(text); got != first {
+					t.Fatalf("DetectLanguage(%q) = %+v, then %+v", text, first, got)
+				}
+			}
+			if first.Lang != "en" {
+				t.Errorf("DetectLanguage(%q) = %+v; want the tie broken for \"en\"", text, first)
+			}
+		}
+	})
+}
+
+func TestCensor_CensorAuto(t *testing.T) {
+	c := NewCensor()
+	c.AddWords([]string{"игра", "яблоко"}, "ru")
+	c.AddWords([]string{"trash"}, "en")
+
+	f := func(c *Censor, text string, expected string, expectedLang string) {
+		t.Helper()
+
+		got, _, detection := c.CensorAuto(text)
+		if got != expected || detection.Lang != expectedLang {
+			t.Errorf("\nCensorAuto(%q)\n\tgot : %s, %q\n\twant: %s, %q", text, got, detection.Lang, expected, expectedLang)
+		}
+	}
+
+	t.Run("detected language", func(t *testing.T) {
+		f(c, "Это та самая игра!", "Это та самая ****!", "ru")
+		f(c, "This game is trash.", "This game is *****.", "en")
+	})
+
+	t.Run("detected language only", func(t *testing.T) {
+		// "trash" is in the english dictionary only
+		f(c, "Мы играли в игры весь вечер, trash", "Мы играли в **** весь вечер, trash", "ru")
+	})
+
+	t.Run("fallback chain", func(t *testing.T) {
+		f(c, "this игра is trash", "this **** is *****", "en")
+		f(c, "", "", "")
+
+		ru := NewCensor(WithFallbackLanguages("ru"))
+		ru.AddWords([]string{"игра"}, "ru")
+		ru.AddWords([]string{"trash"}, "en")
+		f(ru, "this игра is trash", "this **** is trash", "en")
+	})
+
+	t.Run("min confidence", func(t *testing.T) {
+		strict := NewCensor(WithMinConfidence(1), WithFallbackLanguages("en"))
+		strict.AddWords([]string{"игра"}, "ru")
+		strict.AddWords([]string{"trash"}, "en")
+		f(strict, "Это та самая игра, trash!", "Это та самая игра, *****!", "ru")
+	})
+}
@@ -0,0 +1,346 @@
+package ugucensor
+
+import (
+	"bytes"
+	"encoding/base64"
+	"io"
+	"mime"
+	"mime/quotedprintable"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/text/encoding/htmlindex"
+)
+
+// mimeField is a header field of a message or a MIME part, with its raw
+// bytes including folded lines and the line ending.
+type mimeField struct {
+	name string
+	raw  []byte
+}
+
+// mimeEntity is a message or a MIME part split into its header and body.
+type mimeEntity struct {
+	header []mimeField
+	// sep is the blank line ending the header.
+	sep  []byte
+	body []byte
+	eol  string
+}
+
+// mimeWordDecoder decodes encoded-word headers in any charset x/text knows.
+var mimeWordDecoder = &mime.WordDecoder{
+	CharsetReader: func(charset string, input io.Reader) (io.Reader, error) {
+		enc, err := htmlindex.Get(charset)
+		if err != nil {
+			return nil, err
+		}
+		return enc.NewDecoder().Reader(input), nil
+	},
+}
+
+// CensorMessage censors the RFC 5322 email message read from r and writes
+// it to w. The Subject header and the text/plain and text/html parts, in
+// multipart messages and attached messages as well, are decoded from
+// their transfer encoding and charset, censored with CensorText or
+// CensorHTML and encoded back. The parts are written in their original
+// charset when it can hold the censored text, in UTF-8 otherwise; all
+// other headers and parts are copied as they are.
+//
+// It reports whether the message had bad words.
+func (c *Censor) CensorMessage(r io.Reader, w io.Writer, lang string) (bool, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return false, err
+	}
+	out, changed, err := c.censorMessage(data, lang)
+	if err != nil {
+		return false, err
+	}
+	_, err = w.Write(out)
+	return changed, err
+}
+
+func (c *Censor) censorMessage(data []byte, lang string) ([]byte, bool, error) {
+	e := parseMIMEEntity(data)
+
+	subjectChanged := false
+	for i, f := range e.header {
+		if !strings.EqualFold(f.name, "Subject") {
+			continue
+		}
+		subject, err := mimeWordDecoder.DecodeHeader(fieldValue(f))
+		if err != nil {
+			continue
+		}
+		if censored, hasBad := c.CensorText(subject, lang); hasBad {
+			e.header[i].raw = []byte(f.name + ": " + encodeHeader(censored) + e.eol)
+			subjectChanged = true
+		}
+	}
+
+	bodyChanged, err := c.censorEntity(&e, lang)
+	return e.bytes(), subjectChanged || bodyChanged, err
+}
+
+// censorEntity censors the body of a message or a part by its content type.
+func (c *Censor) censorEntity(e *mimeEntity, lang string) (bool, error) {
+	contentType := e.get("Content-Type")
+	if contentType == "" {
+		contentType = "text/plain; charset=us-ascii"
+	}
+	mediaType, params, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		// a part we cannot read is left as it is
+		return false, nil
+	}
+
+	switch {
+	case strings.HasPrefix(mediaType, "multipart/"):
+		if params["boundary"] == "" {
+			return false, nil
+		}
+		return c.censorMultipart(e, params["boundary"], lang)
+
+	case mediaType == "message/rfc822":
+		body, changed, err := c.censorMessage(e.body, lang)
+		e.body = body
+		return changed, err
+
+	case mediaType == "text/plain", mediaType == "text/html":
+		return c.censorTextPart(e, mediaType, params, lang)
+	}
+	return false, nil
+}
+
+// censorMultipart censors every part of a multipart body, keeping the
+// preamble, the epilogue and the delimiters as they are.
+func (c *Censor) censorMultipart(e *mimeEntity, boundary string, lang string) (bool, error) {
+	delimiter := []byte("--" + boundary)
+
+	var (
+		out     bytes.Buffer
+		part    []byte
+		inPart  bool
+		changed bool
+	)
+	flush := func() error {
+		if !inPart {
+			out.Write(part)
+			return nil
+		}
+		// the line ending before a delimiter belongs to the delimiter
+		content := bytes.TrimSuffix(bytes.TrimSuffix(part, []byte("\n")), []byte("\r"))
+		tail := part[len(content):]
+		pe := parseMIMEEntity(content)
+		partChanged, err := c.censorEntity(&pe, lang)
+		if err != nil {
+			return err
+		}
+		if partChanged {
+			content = pe.bytes()
+			changed = true
+		}
+		out.Write(content)
+		out.Write(tail)
+		return nil
+	}
+
+	for _, line := range splitLines(e.body) {
+		rest, isDelimiter := bytes.CutPrefix(bytes.TrimRight(line, " \t\r\n"), delimiter)
+		if !isDelimiter || len(rest) > 0 && !bytes.Equal(rest, []byte("--")) {
+			part = append(part, line...)
+			continue
+		}
+		if err := flush(); err != nil {
+			return false, err
+		}
+		out.Write(line)
+		part = part[:0]
+		inPart = len(rest) == 0
+	}
+	if err := flush(); err != nil {
+		return false, err
+	}
+
+	if changed {
+		e.body = out.Bytes()
+	}
+	return changed, nil
+}
+
+// censorTextPart censors a text/plain or text/html body and encodes it
+// back with its transfer encoding.
+func (c *Censor) censorTextPart(e *mimeEntity, mediaType string, params map[string]string, lang string) (bool, error) {
+	transferEncoding := strings.ToLower(e.get("Content-Transfer-Encoding"))
+
+	var decoded []byte
+	switch transferEncoding {
+	case "quoted-printable":
+		b, err := io.ReadAll(quotedprintable.NewReader(bytes.NewReader(e.body)))
+		if err != nil {
+			return false, nil
+		}
+		decoded = b
+	case "base64":
+		b, err := base64.StdEncoding.DecodeString(string(bytes.Join(bytes.Fields(e.body), nil)))
+		if err != nil {
+			return false, nil
+		}
+		decoded = b
+	default:
+		decoded = e.body
+	}
+
+	charset := params["charset"]
+	if charset == "" || strings.EqualFold(charset, "us-ascii") {
+		// unlabeled 8bit text is nearly always UTF-8, while htmlindex
+		// reads us-ascii as windows-1252
+		charset = "us-ascii"
+		if utf8.Valid(decoded) {
+			charset = "utf-8"
+		}
+	}
+	enc, err := htmlindex.Get(charset)
+	if err != nil {
+		return false, nil
+	}
+	text, err := enc.NewDecoder().Bytes(decoded)
+	if err != nil {
+		return false, nil
+	}
+
+	var censored []byte
+	if mediaType == "text/html" {
+		var out bytes.Buffer
+		if err := c.CensorHTML(bytes.NewReader(text), &out, lang); err != nil {
+			return false, err
+		}
+		censored = out.Bytes()
+	} else {
+		s, _ := c.CensorText(string(text), lang)
+		censored = []byte(s)
+	}
+	if bytes.Equal(censored, text) {
+		return false, nil
+	}
+
+	encoded, err := enc.NewEncoder().Bytes(censored)
+	if err != nil {
+		// the original charset cannot hold the text
+		encoded = censored
+		params["charset"] = "utf-8"
+		e.set("Content-Type", mime.FormatMediaType(mediaType, params))
+	}
+
+	switch transferEncoding {
+	case "quoted-printable":
+		var out bytes.Buffer
+		qp := quotedprintable.NewWriter(&out)
+		qp.Write(encoded)
+		qp.Close()
+		e.body = bytes.ReplaceAll(out.Bytes(), []byte("\r\n"), []byte(e.eol))
+	case "base64":
+		e.body = encodeBase64Lines(encoded, e.eol)
+	default:
+		e.body = encoded
+	}
+	return true, nil
+}
+
+// encodeBase64Lines encodes data in base64 lines of 76 characters.
+func encodeBase64Lines(data []byte, eol string) []byte {
+	const lineLen = 76
+
+	s := base64.StdEncoding.EncodeToString(data)
+	var out bytes.Buffer
+	for len(s) > lineLen {
+		out.WriteString(s[:lineLen])
+		out.WriteString(eol)
+		s = s[lineLen:]
+	}
+	out.WriteString(s)
+	return out.Bytes()
+}
+
+// encodeHeader encodes a header value with encoded-words if it is not ASCII.
+func encodeHeader(s string) string {
+	for i := 0; i < len(s); i++ {
+		if s[i] >= 0x80 {
+			return mime.QEncoding.Encode("utf-8", s)
+		}
+	}
+	return s
+}
+
+// parseMIMEEntity splits data into its header fields and body.
+func parseMIMEEntity(data []byte) mimeEntity {
+	e := mimeEntity{eol: "\r\n"}
+	if i := bytes.IndexByte(data, '\n'); i >= 0 && (i == 0 || data[i-1] != '\r') {
+		e.eol = "\n"
+	}
+
+	lines := splitLines(data)
+	for i, line := range lines {
+		if isBlankLine(line) {
+			e.sep = line
+			e.body = bytes.Join(lines[i+1:], nil)
+			return e
+		}
+		if (line[0] == ' ' || line[0] == '\t') && len(e.header) > 0 {
+			// a folded line continues the field
+			last := &e.header[len(e.header)-1]
+			last.raw = append(last.raw, line...)
+			continue
+		}
+		name, _, _ := bytes.Cut(line, []byte(":"))
+		e.header = append(e.header, mimeField{
+			name: string(bytes.TrimSpace(name)),
+			raw:  append([]byte(nil), line...),
+		})
+	}
+	return e
+}
+
+// fieldValue returns the unfolded value of the header field.
+func fieldValue(f mimeField) string {
+	_, value, _ := bytes.Cut(f.raw, []byte(":"))
+	value = bytes.ReplaceAll(value, []byte("\r\n"), nil)
+	value = bytes.ReplaceAll(value, []byte("\n"), nil)
+	return string(bytes.TrimSpace(value))
+}
+
+// get returns the value of the first header field with the name.
+func (e *mimeEntity) get(name string) string {
+	for _, f := range e.header {
+		if strings.EqualFold(f.name, name) {
+			return fieldValue(f)
+		}
+	}
+	return ""
+}
+
+// set replaces the value of the first header field with the name, or
+// adds the field if there is none.
+func (e *mimeEntity) set(name string, value string) {
+	for i, f := range e.header {
+		if strings.EqualFold(f.name, name) {
+			e.header[i].raw = []byte(f.name + ": " + value + e.eol)
+			return
+		}
+	}
+	e.header = append(e.header, mimeField{name: name, raw: []byte(name + ": " + value + e.eol)})
+	if e.sep == nil {
+		e.sep = []byte(e.eol)
+	}
+}
+
+func (e *mimeEntity) bytes() []byte {
+	var out bytes.Buffer
+	for _, f := range e.header {
+		out.Write(f.raw)
+	}
+	out.Write(e.sep)
+	out.Write(e.body)
+	return out.Bytes()
+}
@@ -0,0 +1,181 @@
+package ugucensor
+
+import (
+	"bytes"
+	"encoding/base64"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"strings"
+	"testing"
+
+	"golang.org/x/text/encoding/charmap"
+)
+
+func censorMessage(t *testing.T, c *Censor, msg string) (string, bool) {
+	t.Helper()
+
+	var out strings.Builder
+	hasBad, err := c.CensorMessage(strings.NewReader(msg), &out, "ru")
+	if err != nil {
+		t.Fatalf("CensorMessage() error: %v", err)
+	}
+	return out.String(), hasBad
+}
+
+func TestCensor_CensorMessage_Plain(t *testing.T) {
+	c := NewCensor()
+	c.AddWord("игра", "ru")
+
+	msg := "From: a@example.com\r\n" +
+		"Subject: =?UTF-8?B?0LjQs9GA0LA=?= today\r\n" +
+		"Content-Type: text/plain; charset=utf-8\r\n" +
+		"X-Note: игра\r\n" +
+		"\r\n" +
+		"Это игра.\r\n"
+
+	got, hasBad := censorMessage(t, c, msg)
+	expected := "From: a@example.com\r\n" +
+		"Subject: **** today\r\n" +
+		"Content-Type: text/plain; charset=utf-8\r\n" +
+		"X-Note: игра\r\n" +
+		"\r\n" +
+		"Это ****.\r\n"
+	if got != expected || !hasBad {
+		t.Errorf("CensorMessage() = %q, %v; want %q, true", got, hasBad, expected)
+	}
+
+	t.Run("unlabeled 8bit", func(t *testing.T) {
+		for _, msg := range []string{
+			"Subject: hi\r\n\r\nЭто игра\r\n",
+			"Subject: hi\r\nContent-Type: text/plain\r\nContent-Transfer-Encoding: 8bit\r\n\r\nЭто игра\r\n",
+			"Subject: hi\r\nContent-Type: text/plain; charset=us-ascii\r\n\r\nЭто игра\r\n",
+		} {
+			got, hasBad := censorMessage(t, c, msg)
+			if expected := strings.Replace(msg, "игра", "****", 1); got != expected || !hasBad {
+				t.Errorf("CensorMessage() = %q, %v; want %q, true", got, hasBad, expected)
+			}
+		}
+	})
+
+	clean := "Subject: hi\r\n\r\nмир\r\n"
+	if got, hasBad := censorMessage(t, c, clean); got != clean || hasBad {
+		t.Errorf("CensorMessage() = %q, %v; want the message unchanged", got, hasBad)
+	}
+}
+
+func TestCensor_CensorMessage_Encodings(t *testing.T) {
+	c := NewCensor()
+	c.AddWord("игра", "ru")
+
+	koi8, _ := charmap.KOI8R.NewEncoder().String("Моя игра\n")
+
+	var qp bytes.Buffer
+	w := quotedprintable.NewWriter(&qp)
+	w.Write([]byte(koi8))
+	w.Close()
+
+	msg := "Subject: test\n" +
+		"Content-Type: text/plain; charset=koi8-r\n" +
+		"Content-Transfer-Encoding: quoted-printable\n" +
+		"\n" +
+		qp.String()
+
+	got, hasBad := censorMessage(t, c, msg)
+	if !hasBad {
+		t.Fatalf("CensorMessage() = %q, false; want a bad word", got)
+	}
+	m, err := mail.ReadMessage(strings.NewReader(got))
+	if err != nil {
+		t.Fatalf("mail.ReadMessage() error: %v", err)
+	}
+	if ct := m.Header.Get("Content-Type"); ct != "text/plain; charset=koi8-r" {
+		t.Errorf("Content-Type = %q; want the charset kept", ct)
+	}
+	body, _ := io.ReadAll(quotedprintable.NewReader(m.Body))
+	text, _ := charmap.KOI8R.NewDecoder().String(string(body))
+	if text != "Моя ****\n" {
+		t.Errorf("body = %q; want %q", text, "Моя ****\n")
+	}
+}
+
+func TestCensor_CensorMessage_Multipart(t *testing.T) {
+	c := NewCensor()
+	c.AddWord("игра", "ru")
+
+	var body bytes.Buffer
+	mw := multipart.NewWriter(&body)
+	plain, _ := mw.CreatePart(map[string][]string{
+		"Content-Type":              {"text/plain; charset=utf-8"},
+		"Content-Transfer-Encoding": {"base64"},
+	})
+	plain.Write([]byte(base64.StdEncoding.EncodeToString([]byte("игра и мир"))))
+	html, _ := mw.CreatePart(map[string][]string{
+		"Content-Type": {"text/html; charset=utf-8"},
+	})
+	html.Write([]byte("<p>иг<b>ра</b></p>"))
+	attachment, _ := mw.CreatePart(map[string][]string{
+		"Content-Type": {"application/octet-stream"},
+	})
+	attachment.Write([]byte("игра"))
+	mw.Close()
+
+	msg := "Subject: hi\r\n" +
+		"MIME-Version: 1.0\r\n" +
+		"Content-Type: multipart/mixed; boundary=" + mw.Boundary() + "\r\n" +
+		"\r\n" +
+		"preamble игра\r\n" +
+		body.String()
+
+	got, hasBad := censorMessage(t, c, msg)
+	if !hasBad {
+		t.Fatalf("CensorMessage() = %q, false; want a bad word", got)
+	}
+
+	m, err := mail.ReadMessage(strings.NewReader(got))
+	if err != nil {
+		t.Fatalf("mail.ReadMessage() error: %v", err)
+	}
+	_, params, _ := mime.ParseMediaType(m.Header.Get("Content-Type"))
+	mr := multipart.NewReader(m.Body, params["boundary"])
+
+	expected := []string{"**** и мир", "<p>**<b>**</b></p>", "игра"}
+	for i, want := range expected {
+		p, err := mr.NextRawPart()
+		if err != nil {
+			t.Fatalf("part %d: %v", i, err)
+		}
+		data, _ := io.ReadAll(p)
+		if p.Header.Get("Content-Transfer-Encoding") == "base64" {
+			data, _ = base64.StdEncoding.DecodeString(string(data))
+		}
+		if string(data) != want {
+			t.Errorf("part %d = %q; want %q", i, data, want)
+		}
+	}
+	if !strings.Contains(got, "preamble игра\r\n") {
+		t.Errorf("CensorMessage() = %q; want the preamble kept", got)
+	}
+}
+
+func TestCensor_CensorMessage_Subject(t *testing.T) {
+	c := NewCensor()
+	c.AddWord("игра", "ru")
+
+	msg := "Subject: =?koi8-r?Q?=C9=C7=D2=C1_=CD=C9=D2?=\n\nhello\n"
+
+	got, hasBad := censorMessage(t, c, msg)
+	if !hasBad {
+		t.Fatalf("CensorMessage() = %q, false; want a bad word", got)
+	}
+	m, err := mail.ReadMessage(strings.NewReader(got))
+	if err != nil {
+		t.Fatalf("mail.ReadMessage() error: %v", err)
+	}
+	subject, _ := mimeWordDecoder.DecodeHeader(m.Header.Get("Subject"))
+	if subject != "**** мир" {
+		t.Errorf("Subject = %q; want %q", subject, "**** мир")
+	}
+}
@@ -0,0 +1,230 @@
+package ugucensor
+
+import (
+	"unicode"
+	"unicode/utf8"
+)
+
+// token is a run of letters in the text.
+type token struct {
+	start int
+	end   int
+}
+
+func letterTokens(runes []rune) []token {
+	var tokens []token
+	for i := 0; i < len(runes); i++ {
+		if !unicode.IsLetter(runes[i]) {
+			continue
+		}
+		start := i
+		for i < len(runes) && unicode.IsLetter(runes[i]) {
+			i++
+		}
+		tokens = append(tokens, token{start, i})
+	}
+	return tokens
+}
+
+// uncoveredTokens returns the tokens not overlapping any of the sorted matches.
+func uncoveredTokens(tokens []token, matches []Match) []token {
+	var result []token
+	j := 0
+	for _, tok := range tokens {
+		for j < len(matches) && matches[j].End <= tok.start {
+			j++
+		}
+		if j < len(matches) && matches[j].Start < tok.end {
+			continue
+		}
+		result = append(result, tok)
+	}
+	return result
+}
+
+// findFuzzyMatches finds words within the edit distance allowed for the
+// severity of an entry among the words not matched yet. The trie keys,
+// which may be stems, only preselect the entries; the distance is measured
+// against the entry and its forms, with typoDistance.
+func (c *Censor) findFuzzyMatches(runes []rune, lang string, found []Match) []Match {
+	maxDist := 0
+	for _, dist := range c.fuzzyDistances {
+		maxDist = max(maxDist, dist)
+	}
+	if maxDist == 0 {
+		return nil
+	}
+
+	dict := c.dicts[lang]
+	stemmer := c.stemmers[lang]
+
+	var matches []Match
+	for _, tok := range uncoveredTokens(letterTokens(runes), found) {
+		if tok.end-tok.start <= maxDist || tok.end-tok.start < c.fuzzyMinLen {
+			continue
+		}
+
+		letters := runes[tok.start:tok.end]
+		word := string(letters)
+		candidates := []string{word}
+		if stemmer != nil {
+			candidates = append(candidates, stemmer.Stem(word))
+		}
+
+		var best *entry
+		bestDist := maxDist + 1
+		checked := make(map[*entry]bool)
+		for _, candidate := range candidates {
+			for _, fm := range dict.FuzzySearch(candidate, maxDist) {
+				e := c.entries[lang][fm.Word]
+				if e == nil || checked[e] {
+					continue
+				}
+				checked[e] = true
+
+				entryLen := utf8.RuneCountInString(e.word)
+				if entryLen < c.fuzzyMinLen {
+					continue
+				}
+				// short entries are a single typo away from many words
+				allowed := min(c.fuzzyDistances[e.severity], (entryLen-1)/2)
+				if dist := entryDistance(letters, e, allowed); dist <= allowed && dist < bestDist {
+					best, bestDist = e, dist
+				}
+			}
+		}
+
+		if best != nil {
+			matches = append(matches, Match{
+				Start:      tok.start,
+				End:        tok.end,
+				Word:       word,
+				Entry:      best.word,
+				Distance:   bestDist,
+				Severity:   best.severity,
+				Category:   best.category,
+				Kind:       MatchFuzzy,
+				Confidence: fuzzyConfidence(bestDist, utf8.RuneCountInString(best.word)),
+			})
+		}
+	}
+	return matches
+}
+
+// entryDistance returns the smallest typoDistance between the word and the
+// entry or its forms, skipping the ones longer or shorter than the word by
+// more than maxDist. It returns maxDist+1 if none is close enough.
+func entryDistance(word []rune, e *entry, maxDist int) int {
+	best := maxDist + 1
+	for _, form := range append([]string{e.word}, e.forms...) {
+		target := []rune(form)
+		if abs(len(target)-len(word)) > maxDist {
+			continue
+		}
+		best = min(best, typoDistance(word, target))
+	}
+	return best
+}
+
+// typoDistance is the edit distance between a and b counting insertions,
+// deletions and swaps of adjacent letters as one edit, and replacements as
+// one edit only when the letters are neighbors on a keyboard, two otherwise:
+// "игрв" is a typo of "игра", "икра" is another word.
+func typoDistance(a []rune, b []rune) int {
+	// rows i-2, i-1 and i of the distance matrix
+	prev2 := make([]int, len(b)+1)
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			replace := 0
+			if a[i-1] != b[j-1] {
+				replace = 2
+				if keyboardNeighbors[a[i-1]][b[j-1]] {
+					replace = 1
+				}
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+replace)
+			if i > 1 && j > 1 && a[i-1] == b[j-2] && a[i-2] == b[j-1] {
+				cur[j] = min(cur[j], prev2[j-2]+1)
+			}
+		}
+		prev2, prev, cur = prev, cur, prev2
+	}
+	return prev[len(b)]
+}
+
+// keyboardNeighbors holds, for every letter, the letters on the keys next
+// to it on the QWERTY layout and the layouts in keyboardLayouts.
+var keyboardNeighbors = newKeyboardNeighbors()
+
+func newKeyboardNeighbors() map[rune]map[rune]bool {
+	qwertyRows := []string{"qwertyuiop[]", "asdfghjkl;'", "zxcvbnm,."}
+
+	layouts := []map[rune]rune{nil}
+	for lang := range keyboardLayouts {
+		layouts = append(layouts, layoutTables[lang])
+	}
+
+	neighbors := make(map[rune]map[rune]bool)
+	link := func(a, b rune) {
+		if !unicode.IsLetter(a) || !unicode.IsLetter(b) {
+			return
+		}
+		if neighbors[a] == nil {
+			neighbors[a] = make(map[rune]bool)
+		}
+		if neighbors[b] == nil {
+			neighbors[b] = make(map[rune]bool)
+		}
+		neighbors[a][b] = true
+		neighbors[b][a] = true
+	}
+
+	for _, table := range layouts {
+		rows := make([][]rune, len(qwertyRows))
+		for r, row := range qwertyRows {
+			for _, key := range row {
+				if table != nil {
+					key = table[key]
+				}
+				rows[r] = append(rows[r], key)
+			}
+		}
+		for r, row := range rows {
+			for i, key := range row {
+				if i+1 < len(row) {
+					link(key, row[i+1])
+				}
+				// the rows are staggered: a key touches the key above it
+				// and the one to its right
+				if r > 0 {
+					for _, k := range []int{i, i + 1} {
+						if k < len(rows[r-1]) {
+							link(key, rows[r-1][k])
+						}
+					}
+				}
+			}
+		}
+	}
+	return neighbors
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+// fuzzyConfidence lowers the confidence of a fuzzy match by the share of
+// the entry's letters that had to be edited.
+func fuzzyConfidence(distance int, entryLen int) float64 {
+	return 1 - float64(distance)/float64(entryLen)
+}
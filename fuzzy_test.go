@@ -0,0 +1,93 @@
+package ugucensor
+
+import "testing"
+
+func TestCensor_CensorText_Fuzzy(t *testing.T) {
+	c := NewCensor(WithFuzzyMatching(4))
+	c.AddWord("игра", "ru", EntrySeverity(SeverityHigh))
+	c.AddWord("яблоко", "ru")
+	c.AddWord("помидор", "ru", EntrySeverity(SeverityLow))
+	c.AddWord("кот", "ru", EntrySeverity(SeverityHigh))
+
+	f := func(text string, expected string, expectedCensored bool) {
+		t.Helper()
+
+		got, gotCensored := c.CensorText(text, "ru")
+		if got != expected || gotCensored != expectedCensored {
+			t.Errorf("\nCensorText(%q, \"ru\")\n\tgot : %s, %v\n\twant: %s, %v", text, got, gotCensored, expected, expectedCensored)
+		}
+	}
+
+	t.Run("exact matches", func(t *testing.T) {
+		f("игра и яблоко", "**** и ******", true)
+	})
+
+	t.Run("misspellings", func(t *testing.T) {
+		f("это игрв", "это ****", true)
+		f("это ирга!", "это ****!", true)
+		f("Ирга, игрв", "****, ****", true)
+		f("сочное яблко", "сочное *****", true)
+		f("сочное ябллоко", "сочное *******", true)
+	})
+
+	t.Run("distance per severity", func(t *testing.T) {
+		f("сочное ялбко", "сочное ялбко", false)
+		f("помидор", "*******", true)
+		f("памидор", "памидор", false)
+	})
+
+	t.Run("minimum length", func(t *testing.T) {
+		f("кот", "***", true)
+		f("кит", "кит", false)
+	})
+
+	t.Run("common words", func(t *testing.T) {
+		for _, word := range []string{"гора", "икра", "угар", "игла", "тигр", "ира", "яблоня", "яблони"} {
+			f(word, word, false)
+		}
+	})
+}
+
+func TestTypoDistance(t *testing.T) {
+	f := func(a string, b string, expected int) {
+		t.Helper()
+
+		if got := typoDistance([]rune(a), []rune(b)); got != expected {
+			t.Errorf("typoDistance(%q, %q) = %d; want %d", a, b, got, expected)
+		}
+	}
+
+	f("игра", "игра", 0)
+	f("игрв", "игра", 1)
+	f("ирга", "игра", 1)
+	f("игр", "игра", 1)
+	f("икра", "игра", 2)
+	f("gane", "game", 1)
+	f("gale", "game", 2)
+	f("", "игра", 4)
+}
+
+func TestCensor_FindMatches_Fuzzy(t *testing.T) {
+	c := NewCensor(WithFuzzyMatching(4), WithFuzzyDistance(SeverityMedium, 2))
+	c.AddWord("яблоко", "ru")
+
+	f := func(text string, expected ...Match) {
+		t.Helper()
+
+		got := c.FindMatches(text, "ru")
+		if len(got) != len(expected) {
+			t.Errorf("FindMatches(%q) = %+v; want %+v", text, got, expected)
+			return
+		}
+		for i := range got {
+			if got[i] != expected[i] {
+				t.Errorf("FindMatches(%q) = %+v; want %+v", text, got, expected)
+				return
+			}
+		}
+	}
+
+	f("яблоко", Match{Start: 0, End: 6, ByteEnd: 12, UTF16End: 6, Word: "яблоко", Entry: "яблоко", Severity: SeverityMedium, Confidence: 1})
+	f("и ялбко", Match{Start: 2, End: 7, ByteStart: 3, ByteEnd: 13, UTF16Start: 2, UTF16End: 7, Word: "ялбко", Entry: "яблоко", Distance: 2, Severity: SeverityMedium, Kind: MatchFuzzy, Confidence: fuzzyConfidence(2, 6)})
+	f("и ябблоко", Match{Start: 2, End: 9, ByteStart: 3, ByteEnd: 17, UTF16Start: 2, UTF16End: 9, Word: "ябблоко", Entry: "яблоко", Distance: 1, Severity: SeverityMedium, Kind: MatchFuzzy, Confidence: fuzzyConfidence(1, 6)})
+}
@@ -2,4 +2,10 @@ module github.com/machine23/ugu-censor
 
 go 1.22.5
 
-require github.com/machine23/ugu-stemmer v0.0.0-20240710172113-e3648027c796
+require (
+	github.com/machine23/ugu-stemmer v0.0.0-20240710172113-e3648027c796
+	github.com/rivo/uniseg v0.4.7
+	github.com/yuin/goldmark v1.7.8
+	golang.org/x/net v0.33.0
+	golang.org/x/text v0.21.0
+)
@@ -0,0 +1,110 @@
+package ugucensor
+
+import (
+	"html"
+	"strings"
+)
+
+// Highlighter renders the text of Highlight with markers around the
+// matched words.
+type Highlighter struct {
+	// Open and Close return the markers put before and after a match.
+	Open  func(m Match) string
+	Close func(m Match) string
+	// Escape, if set, escapes the text inside and outside the matches;
+	// the markers are written as they are.
+	Escape func(s string) string
+}
+
+// HTMLHighlighter wraps matches in <mark> elements with the category and
+// the severity of the entry, and escapes the rest of the text:
+//
+//	<mark data-category="slur" data-severity="high">игра</mark>
+func HTMLHighlighter() Highlighter {
+	return Highlighter{
+		Open: func(m Match) string {
+			var sb strings.Builder
+			sb.WriteString("<mark")
+			if m.Category != "" {
+				sb.WriteString(` data-category="`)
+				sb.WriteString(html.EscapeString(m.Category))
+				sb.WriteByte('"')
+			}
+			sb.WriteString(` data-severity="`)
+			sb.WriteString(m.Severity.String())
+			sb.WriteString(`">`)
+			return sb.String()
+		},
+		Close: func(Match) string {
+			return "</mark>"
+		},
+		Escape: html.EscapeString,
+	}
+}
+
+// ansiSeverityColors are the terminal colors of matches by severity.
+var ansiSeverityColors = map[Severity]string{
+	SeverityLow:    "\x1b[33m",
+	SeverityMedium: "\x1b[31m",
+	SeverityHigh:   "\x1b[1;31m",
+}
+
+// ANSIHighlighter colors matches for terminals by their severity: yellow
+// for low, red for medium and bold red for high. Escape characters in the
+// text are shown as "^[", so the text cannot change the terminal state.
+func ANSIHighlighter() Highlighter {
+	return Highlighter{
+		Open: func(m Match) string {
+			if color, ok := ansiSeverityColors[m.Severity]; ok {
+				return color
+			}
+			return ansiSeverityColors[SeverityMedium]
+		},
+		Close: func(Match) string {
+			return "\x1b[0m"
+		},
+		Escape: func(s string) string {
+			return strings.ReplaceAll(s, "\x1b", "^[")
+		},
+	}
+}
+
+// Highlight returns the text with the bad words wrapped in the markers of
+// the highlighter instead of masked, for moderators to review. The words
+// are found the same way CensorText finds the words it masks, and with
+// WithStripInvisible the invisible characters are dropped as CensorText
+// drops them. It reports whether the text had bad words.
+func (c *Censor) Highlight(text string, lang string, h Highlighter) (string, bool) {
+	runes := []rune(text)
+
+	var result strings.Builder
+	write := func(s []rune) {
+		s = c.visibleRunes(s)
+		if h.Escape != nil {
+			result.WriteString(h.Escape(string(s)))
+		} else {
+			result.WriteString(string(s))
+		}
+	}
+
+	matches := c.findMatches(runes, lang)
+	if len(matches) == 0 {
+		write(runes)
+		return result.String(), false
+	}
+
+	prev := 0
+	for _, m := range matches {
+		write(runes[prev:m.Start])
+		if h.Open != nil {
+			result.WriteString(h.Open(m))
+		}
+		write(runes[m.Start:m.End])
+		if h.Close != nil {
+			result.WriteString(h.Close(m))
+		}
+		prev = m.End
+	}
+	write(runes[prev:])
+	return result.String(), true
+}
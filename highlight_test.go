@@ -0,0 +1,103 @@
+package ugucensor
+
+import (
+	"testing"
+)
+
+func TestCensor_Highlight(t *testing.T) {
+	c := NewCensor()
+	c.AddWord("игра", "ru", EntryCategory("slur"), EntrySeverity(SeverityHigh))
+	c.AddWord("яблоко", "ru")
+
+	f := func(h Highlighter, text string, expected string, expectedCensored bool) {
+		t.Helper()
+
+		got, gotCensored := c.Highlight(text, "ru", h)
+		if got != expected || gotCensored != expectedCensored {
+			t.Errorf("Highlight(%q) = %q, %v; want %q, %v", text, got, gotCensored, expected, expectedCensored)
+		}
+	}
+
+	t.Run("HTML", func(t *testing.T) {
+		h := HTMLHighlighter()
+		f(h, "Это игра", `Это <mark data-category="slur" data-severity="high">игра</mark>`, true)
+		f(h, "<b>яблоко</b> & игры", `&lt;b&gt;<mark data-severity="medium">яблоко</mark>&lt;/b&gt; &amp; <mark data-category="slur" data-severity="high">игры</mark>`, true)
+		f(h, "мир <i>", "мир &lt;i&gt;", false)
+	})
+
+	t.Run("ANSI", func(t *testing.T) {
+		h := ANSIHighlighter()
+		f(h, "игра и яблоко", "\x1b[1;31mигра\x1b[0m и \x1b[31mяблоко\x1b[0m", true)
+		f(h, "мир \x1b[2J", "мир ^[[2J", false)
+	})
+
+	t.Run("custom markers", func(t *testing.T) {
+		h := Highlighter{
+			Open:  func(m Match) string { return "[" + m.Entry + ":" },
+			Close: func(Match) string { return "]" },
+		}
+		f(h, "и.г.р.а <яблоко>", "[игра:и.г.р.а] <[яблоко:яблоко]>", true)
+	})
+
+	t.Run("invisible characters", func(t *testing.T) {
+		c := NewCensor(WithStripInvisible())
+		c.AddWord("игра", "ru")
+		h := Highlighter{
+			Open:  func(Match) string { return "[" },
+			Close: func(Match) string { return "]" },
+		}
+
+		for _, tc := range []struct{ text, expected string }{
+			{"ми\u200bр и\u200bгра", "мир [игра]"},
+			{"ми\u200bр", "мир"},
+		} {
+			if got, _ := c.Highlight(tc.text, "ru", h); got != tc.expected {
+				t.Errorf("Highlight(%q) = %q; want %q", tc.text, got, tc.expected)
+			}
+		}
+	})
+
+	t.Run("same spans as CensorText", func(t *testing.T) {
+		c := NewCensor(WithWildcards(1), WithPrefixMatching())
+		c.AddWords([]string{"игра", "яблоко"}, "ru")
+		h := Highlighter{
+			Open:  func(Match) string { return "[" },
+			Close: func(Match) string { return "]" },
+		}
+		for _, text := range []string{"поиграть в и*ра", "и г р а", "яблоки, игрок"} {
+			censored, _ := c.CensorText(text, "ru")
+			highlighted, _ := c.Highlight(text, "ru", h)
+
+			var masked []rune
+			in := false
+			for _, ch := range highlighted {
+				switch {
+				case ch == '[':
+					in = true
+				case ch == ']':
+					in = false
+				case in && !isInvisible(ch):
+					masked = append(masked, '*')
+				default:
+					masked = append(masked, ch)
+				}
+			}
+			if string(masked) != censored {
+				t.Errorf("Highlight(%q) = %q, masks %q; CensorText = %q", text, highlighted, string(masked), censored)
+			}
+		}
+	})
+}
+
+func TestSeverity_String(t *testing.T) {
+	for severity, expected := range map[Severity]string{
+		SeverityLow:    "low",
+		SeverityMedium: "medium",
+		SeverityHigh:   "high",
+		Severity(7):    "Severity(7)",
+	} {
+		if got := severity.String(); got != expected {
+			t.Errorf("Severity(%d).String() = %q; want %q", int(severity), got, expected)
+		}
+	}
+}
@@ -0,0 +1,275 @@
+package ugucensor
+
+import (
+	"bytes"
+	"io"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// defaultHTMLAttributes are the attributes censored by CensorHTML when
+// WithHTMLAttributes is given none.
+var defaultHTMLAttributes = []string{"alt", "title"}
+
+// inlineElements are the elements that do not break words, so a word may
+// continue across their tags: "иг<b>ра</b>".
+var inlineElements = map[atom.Atom]bool{
+	atom.A: true, atom.Abbr: true, atom.B: true, atom.Bdi: true, atom.Bdo: true,
+	atom.Cite: true, atom.Code: true, atom.Data: true, atom.Del: true,
+	atom.Dfn: true, atom.Em: true, atom.Font: true, atom.I: true,
+	atom.Ins: true, atom.Kbd: true, atom.Mark: true, atom.Q: true,
+	atom.S: true, atom.Samp: true, atom.Small: true, atom.Span: true,
+	atom.Strike: true, atom.Strong: true, atom.Sub: true, atom.Sup: true,
+	atom.Time: true, atom.Tt: true, atom.U: true, atom.Var: true,
+	atom.Wbr: true,
+}
+
+// htmlPiece is a token of a run of text and inline tags.
+type htmlPiece struct {
+	raw []byte
+	// text is the decoded text of a text token.
+	text   string
+	isText bool
+}
+
+// CensorHTML censors the text of the HTML document read from r and writes
+// the document to w. Only text is censored: tags, comments, scripts and
+// styles are written as they are, along with the text nodes having no bad
+// words. Entities are decoded before matching, and words are matched
+// across inline tags like <b> or <span>, so "иг<b>ра</b>" is masked as
+// "**<b>**</b>". With WithHTMLAttributes the alt and title attributes, or
+// the ones given, are censored as well.
+func (c *Censor) CensorHTML(r io.Reader, w io.Writer, lang string) error {
+	z := html.NewTokenizer(r)
+
+	var (
+		run     []htmlPiece
+		rawText bool
+	)
+	flush := func() error {
+		err := c.writeHTMLRun(w, run, lang)
+		run = run[:0]
+		return err
+	}
+
+	for {
+		tt := z.Next()
+		if tt == html.ErrorToken {
+			if z.Err() != io.EOF {
+				return z.Err()
+			}
+			return flush()
+		}
+
+		raw := bytes.Clone(z.Raw())
+		switch tt {
+		case html.TextToken:
+			if rawText {
+				break
+			}
+			run = append(run, htmlPiece{raw: raw, text: string(z.Text()), isText: true})
+			continue
+
+		case html.StartTagToken, html.SelfClosingTagToken, html.EndTagToken:
+			tok := z.Token()
+			if tok.DataAtom == atom.Script || tok.DataAtom == atom.Style {
+				rawText = tt == html.StartTagToken
+			}
+			if tt != html.EndTagToken && c.censorHTMLAttributes(&tok, lang) {
+				raw = []byte(tok.String())
+			}
+			if inlineElements[tok.DataAtom] {
+				run = append(run, htmlPiece{raw: raw})
+				continue
+			}
+		}
+
+		if err := flush(); err != nil {
+			return err
+		}
+		if _, err := w.Write(raw); err != nil {
+			return err
+		}
+	}
+}
+
+// writeHTMLRun censors the text of a run of text and inline tags as a
+// whole and writes it. Text tokens having no bad words are written as
+// they were.
+func (c *Censor) writeHTMLRun(w io.Writer, run []htmlPiece, lang string) error {
+	var runes []rune
+	starts := make([]int, len(run))
+	for i, piece := range run {
+		starts[i] = len(runes)
+		runes = append(runes, []rune(piece.text)...)
+	}
+	matches := c.findMatches(runes, lang)
+
+	for i, piece := range run {
+		out := piece.raw
+		if piece.isText {
+			start, end := starts[i], starts[i]+len([]rune(piece.text))
+			out = c.maskHTMLText(piece.raw, runes[start:end], clipMatches(matches, start, end))
+		}
+		if _, err := w.Write(out); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// htmlTextUnit is a character or an entity of the raw text of a text token
+// and the span of the decoded runes it stands for.
+type htmlTextUnit struct {
+	rawStart, rawEnd int
+	start, end       int
+}
+
+// maskHTMLText masks the matches of the decoded text of a text token in
+// its raw bytes, which are kept as written everywhere else: entities stay
+// encoded and no character gets escaped anew.
+func (c *Censor) maskHTMLText(raw []byte, text []rune, matches []Match) []byte {
+	units, ok := htmlTextUnits(raw, text)
+	if !ok {
+		// the raw text does not line up with the decoded one
+		masked := c.maskMatches(text, matches)
+		if masked == string(text) {
+			return raw
+		}
+		return []byte(html.EscapeString(masked))
+	}
+
+	var (
+		out  []byte
+		mask strings.Builder
+		u    int
+	)
+	for _, m := range matches {
+		for ; u < len(units) && units[u].end <= m.Start; u++ {
+			out = c.appendHTMLUnit(out, raw, text, units[u])
+		}
+		if u == len(units) || units[u].start >= m.End {
+			// masked along with the entity of the previous match
+			continue
+		}
+		// an entity partly matched is masked whole
+		start, end := units[u].start, m.End
+		for ; u < len(units) && units[u].start < m.End; u++ {
+			end = max(end, units[u].end)
+		}
+		mask.Reset()
+		writeMask(&mask, text[start:end], MaskFull)
+		out = append(out, mask.String()...)
+	}
+	for ; u < len(units); u++ {
+		out = c.appendHTMLUnit(out, raw, text, units[u])
+	}
+	return out
+}
+
+// appendHTMLUnit appends the raw bytes of the unit, unless it stands for
+// invisible characters only and the Censor was created with
+// WithStripInvisible.
+func (c *Censor) appendHTMLUnit(out []byte, raw []byte, text []rune, unit htmlTextUnit) []byte {
+	if c.stripInvisible && !slices.ContainsFunc(text[unit.start:unit.end], func(ch rune) bool {
+		return !isInvisible(ch)
+	}) {
+		return out
+	}
+	return append(out, raw[unit.rawStart:unit.rawEnd]...)
+}
+
+// htmlTextUnits splits the raw text of a text token into the characters
+// and entities its decoded text is made of, or reports false if the two
+// do not line up.
+func htmlTextUnits(raw []byte, text []rune) ([]htmlTextUnit, bool) {
+	units := make([]htmlTextUnit, 0, len(text))
+	p, i := 0, 0
+	for p < len(raw) && i < len(text) {
+		decoded, size := []rune(nil), 0
+		switch ch, n := utf8.DecodeRune(raw[p:]); {
+		case ch == '&':
+			end := htmlEntityEnd(raw, p)
+			decoded = []rune(html.UnescapeString(string(raw[p:end])))
+			size = end - p
+			if string(decoded) == string(raw[p:end]) {
+				// not an entity, just an ampersand
+				decoded, size = []rune{ch}, n
+			}
+		case ch == '\r':
+			// the tokenizer reads "\r\n" and "\r" as "\n"
+			decoded, size = []rune{'\n'}, n
+			if p+1 < len(raw) && raw[p+1] == '\n' {
+				size++
+			}
+		default:
+			decoded, size = []rune{ch}, n
+		}
+
+		if i+len(decoded) > len(text) || string(text[i:i+len(decoded)]) != string(decoded) {
+			return nil, false
+		}
+		units = append(units, htmlTextUnit{p, p + size, i, i + len(decoded)})
+		p, i = p+size, i+len(decoded)
+	}
+	return units, p == len(raw) && i == len(text)
+}
+
+// htmlEntityEnd returns the end of the character reference that may start
+// at the ampersand at p: "&amp;", "&#1080;", "&#x438;" or a legacy one
+// without the semicolon like "&nbsp".
+func htmlEntityEnd(raw []byte, p int) int {
+	end := p + 1
+	if end < len(raw) && raw[end] == '#' {
+		end++
+		if end < len(raw) && (raw[end] == 'x' || raw[end] == 'X') {
+			end++
+		}
+	}
+	for end < len(raw) && isASCIIAlnum(raw[end]) {
+		end++
+	}
+	if end < len(raw) && raw[end] == ';' {
+		end++
+	}
+	return end
+}
+
+func isASCIIAlnum(b byte) bool {
+	return 'a' <= b && b <= 'z' || 'A' <= b && b <= 'Z' || '0' <= b && b <= '9'
+}
+
+// censorHTMLAttributes censors the values of the attributes configured
+// with WithHTMLAttributes and reports whether any of them changed.
+func (c *Censor) censorHTMLAttributes(tok *html.Token, lang string) bool {
+	changed := false
+	for i, attr := range tok.Attr {
+		if attr.Namespace != "" || !c.htmlAttributes[attr.Key] {
+			continue
+		}
+		if masked, hasBad := c.twoPassCensorText(attr.Val, lang); hasBad || masked != attr.Val {
+			tok.Attr[i].Val = masked
+			changed = true
+		}
+	}
+	return changed
+}
+
+// clipMatches returns the parts of the sorted matches within [start, end),
+// with offsets relative to start.
+func clipMatches(matches []Match, start int, end int) []Match {
+	var clipped []Match
+	for _, m := range matches {
+		if m.End <= start || m.Start >= end {
+			continue
+		}
+		m.Start = max(m.Start, start) - start
+		m.End = min(m.End, end) - start
+		clipped = append(clipped, m)
+	}
+	return clipped
+}
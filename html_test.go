@@ -0,0 +1,78 @@
+package ugucensor
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCensor_CensorHTML(t *testing.T) {
+	c := NewCensor()
+	c.AddWords([]string{"игра", "яблоко"}, "ru")
+
+	f := func(src string, expected string) {
+		t.Helper()
+
+		var out strings.Builder
+		if err := c.CensorHTML(strings.NewReader(src), &out, "ru"); err != nil {
+			t.Fatalf("CensorHTML(%q) error: %v", src, err)
+		}
+		if got := out.String(); got != expected {
+			t.Errorf("CensorHTML(%q) = %q; want %q", src, got, expected)
+		}
+	}
+
+	t.Run("text nodes", func(t *testing.T) {
+		f("<p>хорошая игра</p>", "<p>хорошая ****</p>")
+		f("<div class=\"игра\">игра</div>", "<div class=\"игра\">****</div>")
+		f("<p>привет</p>", "<p>привет</p>")
+	})
+
+	t.Run("markup is kept", func(t *testing.T) {
+		f("<p  CLASS='x'>игра<br/>мир</p><!-- игра -->", "<p  CLASS='x'>****<br/>мир</p><!-- игра -->")
+		f("<script>var игра = 1</script><style>.игра{}</style>", "<script>var игра = 1</script><style>.игра{}</style>")
+	})
+
+	t.Run("entities", func(t *testing.T) {
+		f("<p>Tom &amp; Jerry</p>", "<p>Tom &amp; Jerry</p>")
+		f("<p>&#1080;гра &amp; мир</p>", "<p>**** &amp; мир</p>")
+		f("<p>игра &lt;3</p>", "<p>**** &lt;3</p>")
+		f("<p>Tom's игра</p>", "<p>Tom's ****</p>")
+		f("<p>игра&nbsp;&#1084;ир &quot;x&quot; &amp &copy</p>", "<p>****&nbsp;&#1084;ир &quot;x&quot; &amp &copy</p>")
+		f("<p>и&#1075;ра & мир > 1</p>", "<p>**** & мир > 1</p>")
+		f("<p>мир\r\nигра\rмир\r\n</p>", "<p>мир\r\n****\rмир\r\n</p>")
+	})
+
+	t.Run("inline elements", func(t *testing.T) {
+		f("<p>иг<b>ра</b></p>", "<p>**<b>**</b></p>")
+		f("<p>ябл<span class=\"x\">о</span>ко!</p>", "<p>***<span class=\"x\">*</span>**!</p>")
+	})
+
+	t.Run("block elements break words", func(t *testing.T) {
+		f("<div>иг</div><div>ра</div>", "<div>иг</div><div>ра</div>")
+	})
+
+	t.Run("attributes are not censored by default", func(t *testing.T) {
+		f("<img alt=\"игра\">", "<img alt=\"игра\">")
+	})
+}
+
+func TestCensor_CensorHTML_Attributes(t *testing.T) {
+	c := NewCensor(WithHTMLAttributes())
+	c.AddWord("игра", "ru")
+
+	f := func(src string, expected string) {
+		t.Helper()
+
+		var out strings.Builder
+		if err := c.CensorHTML(strings.NewReader(src), &out, "ru"); err != nil {
+			t.Fatalf("CensorHTML(%q) error: %v", src, err)
+		}
+		if got := out.String(); got != expected {
+			t.Errorf("CensorHTML(%q) = %q; want %q", src, got, expected)
+		}
+	}
+
+	f("<img alt='игра' src=\"игра.png\">", "<img alt=\"****\" src=\"игра.png\">")
+	f("<a title=\"мир\" href=\"/\">игра</a>", "<a title=\"мир\" href=\"/\">****</a>")
+	f("<p title=\"игра\"/>", "<p title=\"****\"/>")
+}
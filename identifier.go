@@ -0,0 +1,114 @@
+package ugucensor
+
+import "unicode"
+
+// leetTables maps the digits and symbols used in place of letters to the
+// letters of the script they stand for.
+var leetTables = map[*unicode.RangeTable]map[rune]rune{
+	unicode.Latin: {
+		'0': 'o', '1': 'i', '3': 'e', '4': 'a', '5': 's', '7': 't', '8': 'b',
+		'@': 'a', '$': 's', '!': 'i', '|': 'l',
+	},
+	unicode.Cyrillic: {
+		'0': 'о', '3': 'з', '4': 'ч', '6': 'б', '@': 'а',
+	},
+}
+
+// identifierInfixMinLen is the minimum length of entries matched inside
+// the parts of an identifier unless WithInfixMatching sets a longer one.
+const identifierInfixMinLen = 3
+
+// ValidateIdentifier checks a username or another identifier like
+// "xX_igra_Xx" or "1gr4_pro" against the dictionaries of langs, or of all
+// languages if langs is empty, and reports whether it is clean along with
+// the matches found.
+//
+// Identifiers have no word boundaries, so they are checked more
+// aggressively than text: digits, underscores and other symbols separate
+// words, camelCase words are split, dictionary words are found inside
+// longer ones, and transliteration, wrong keyboard layouts and leetspeak
+// ("1gr4" for "igra") are recognized. Words allowed with AllowWord are
+// respected.
+func (c *Censor) ValidateIdentifier(s string, langs ...string) (bool, []Match) {
+	runes := []rune(s)
+	vc := c.identifierProfile()
+
+	var matches []Match
+	for _, reading := range identifierReadings(runes) {
+		matches = append(matches, vc.findMixedMatches(reading, langs)...)
+	}
+	matches = mergeMatches(matches)
+	// the readings have the runes of s, but not its bytes
+	setOffsets(runes, matches)
+
+	return len(matches) == 0, matches
+}
+
+// identifierProfile returns a copy of the Censor sharing its dictionaries
+// with the matching modes used for identifiers enabled.
+func (c *Censor) identifierProfile() *Censor {
+	vc := *c
+	vc.transliteration = true
+	vc.layoutMatching = true
+	vc.wordSplitting = true
+	if vc.infixMinLen == 0 {
+		vc.infixMinLen = identifierInfixMinLen
+	}
+	return &vc
+}
+
+// identifierReadings returns the ways to read the identifier as text of
+// the same length: with every symbol as a separator, and with the symbols
+// standing for letters in leetspeak decoded for every script used in it.
+func identifierReadings(runes []rune) [][]rune {
+	separated := make([]rune, len(runes))
+	scripts := make(map[*unicode.RangeTable]bool)
+	for i, ch := range runes {
+		separated[i] = ' '
+		if unicode.IsLetter(ch) {
+			separated[i] = ch
+			if script := scriptOf(ch); script != nil {
+				scripts[script] = true
+			}
+		}
+	}
+	readings := [][]rune{separated}
+
+	for _, script := range []*unicode.RangeTable{unicode.Latin, unicode.Cyrillic} {
+		if !scripts[script] {
+			continue
+		}
+		table := leetTables[script]
+		decoded := make([]rune, len(runes))
+		for i, ch := range runes {
+			decoded[i] = separated[i]
+			if letter, ok := table[ch]; ok && nextToLetter(runes, i, table) {
+				decoded[i] = letter
+			}
+		}
+		readings = append(readings, decoded)
+	}
+	return readings
+}
+
+// nextToLetter reports whether the leetspeak symbol at i is part of a word:
+// it is joined to a letter directly or through other leetspeak symbols.
+func nextToLetter(runes []rune, i int, table map[rune]rune) bool {
+	for j := i - 1; j >= 0; j-- {
+		if unicode.IsLetter(runes[j]) {
+			return true
+		}
+		if _, ok := table[runes[j]]; !ok {
+			break
+		}
+	}
+	for j := i + 1; j < len(runes); j++ {
+		if unicode.IsLetter(runes[j]) {
+			return true
+		}
+		if _, ok := table[runes[j]]; !ok {
+			break
+		}
+	}
+	return false
+}
@@ -0,0 +1,74 @@
+package ugucensor
+
+import "testing"
+
+func TestCensor_ValidateIdentifier(t *testing.T) {
+	c := NewCensor()
+	c.AddWords([]string{"игра", "яблоко"}, "ru")
+	c.AddWord("game", "en")
+	c.AllowWord("pigra", "ru")
+
+	f := func(s string, expectedOK bool, expectedSpans ...[2]int) {
+		t.Helper()
+
+		ok, matches := c.ValidateIdentifier(s)
+		if ok != expectedOK || len(matches) != len(expectedSpans) {
+			t.Errorf("ValidateIdentifier(%q) = %v, %+v; want %v, %v", s, ok, matches, expectedOK, expectedSpans)
+			return
+		}
+		for i, m := range matches {
+			if m.Start != expectedSpans[i][0] || m.End != expectedSpans[i][1] {
+				t.Errorf("ValidateIdentifier(%q) = %v, %+v; want %v, %v", s, ok, matches, expectedOK, expectedSpans)
+				return
+			}
+		}
+	}
+
+	t.Run("separators", func(t *testing.T) {
+		f("xX_igra_Xx", false, [2]int{3, 7})
+		f("igra1337", false, [2]int{0, 4})
+		f("игра.pro", false, [2]int{0, 4})
+	})
+
+	t.Run("camelCase and compounds", func(t *testing.T) {
+		f("SuperIgraPro", false, [2]int{5, 9})
+		f("суперигра", false, [2]int{5, 9})
+		f("bestgamever", false, [2]int{4, 8})
+	})
+
+	t.Run("leetspeak", func(t *testing.T) {
+		f("1gr4", false, [2]int{0, 4})
+		f("g4me_over", false, [2]int{0, 4})
+		f("ябл0к0", false, [2]int{0, 6})
+	})
+
+	t.Run("several languages", func(t *testing.T) {
+		f("igra_game", false, [2]int{0, 4}, [2]int{5, 9})
+	})
+
+	t.Run("clean identifiers", func(t *testing.T) {
+		f("nice_user42", true)
+		f("pigra", true)
+		f("", true)
+	})
+}
+
+func TestCensor_ValidateIdentifier_Langs(t *testing.T) {
+	c := NewCensor()
+	c.AddWord("игра", "ru")
+	c.AddWord("game", "en")
+
+	if ok, matches := c.ValidateIdentifier("igra_game", "en"); ok || len(matches) != 1 || matches[0].Entry != "game" {
+		t.Errorf("ValidateIdentifier() = %v, %+v; want the English word only", ok, matches)
+	}
+}
+
+func TestCensor_ValidateIdentifier_KeepsOptions(t *testing.T) {
+	c := NewCensor()
+	c.AddWord("игра", "ru")
+	c.ValidateIdentifier("igra")
+
+	if got, hasBad := c.CensorText("igra", "ru"); hasBad {
+		t.Errorf("CensorText() = %q, %v; want the Censor options unchanged", got, hasBad)
+	}
+}
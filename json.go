@@ -0,0 +1,452 @@
+package ugucensor
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// jsonStep is a step of a JSON path selector: a key, an index, or any key
+// or index.
+type jsonStep struct {
+	key   string
+	index int
+	// isIndex marks array steps; any marks the "*" wildcard, which
+	// matches keys and indices alike.
+	isIndex bool
+	any     bool
+}
+
+// jsonElem is an element of the path to a value in a JSON document.
+type jsonElem struct {
+	key     string
+	index   int
+	isIndex bool
+}
+
+// CensorJSON censors the string values of the JSON document read from r
+// selected by the paths and writes the document to w, keeping the key
+// order and the formatting of everything else. It returns the paths of the
+// values changed, in document order.
+//
+// A path starts with "$" followed by steps: ".name" or `["name"]` selects
+// a key of an object, "[2]" an element of an array, and ".*" or "[*]" any
+// key or element: "$.comments[*].body", "$.user.bio". Values of other
+// types than string are never changed.
+func (c *Censor) CensorJSON(r io.Reader, w io.Writer, lang string, paths ...string) ([]string, error) {
+	selectors := make([][]jsonStep, 0, len(paths))
+	for _, path := range paths {
+		selector, err := parseJSONPath(path)
+		if err != nil {
+			return nil, err
+		}
+		selectors = append(selectors, selector)
+	}
+
+	jc := &jsonCensor{
+		c:         c,
+		lang:      lang,
+		selectors: selectors,
+		r:         bufio.NewReader(r),
+		w:         bufio.NewWriter(w),
+	}
+	if err := jc.document(); err != nil {
+		return jc.changed, err
+	}
+	return jc.changed, jc.w.Flush()
+}
+
+// parseJSONPath parses a path selector of CensorJSON.
+func parseJSONPath(path string) ([]jsonStep, error) {
+	if !strings.HasPrefix(path, "$") {
+		return nil, fmt.Errorf("ugucensor: JSON path %q does not start with $", path)
+	}
+
+	var steps []jsonStep
+	for rest := path[1:]; rest != ""; {
+		switch {
+		case strings.HasPrefix(rest, ".*"):
+			steps = append(steps, jsonStep{any: true})
+			rest = rest[2:]
+
+		case rest[0] == '.':
+			end := strings.IndexAny(rest[1:], ".[")
+			if end < 0 {
+				end = len(rest) - 1
+			}
+			if end == 0 {
+				return nil, fmt.Errorf("ugucensor: empty key in JSON path %q", path)
+			}
+			steps = append(steps, jsonStep{key: rest[1 : end+1]})
+			rest = rest[end+1:]
+
+		case rest[0] == '[':
+			end := strings.IndexByte(rest, ']')
+			if end < 0 {
+				return nil, fmt.Errorf("ugucensor: unclosed [ in JSON path %q", path)
+			}
+			inner := rest[1:end]
+			switch {
+			case inner == "*":
+				steps = append(steps, jsonStep{isIndex: true, any: true})
+			case strings.HasPrefix(inner, `"`):
+				key, err := strconv.Unquote(inner)
+				if err != nil {
+					return nil, fmt.Errorf("ugucensor: bad key %s in JSON path %q", inner, path)
+				}
+				steps = append(steps, jsonStep{key: key})
+			default:
+				index, err := strconv.Atoi(inner)
+				if err != nil || index < 0 {
+					return nil, fmt.Errorf("ugucensor: bad index %q in JSON path %q", inner, path)
+				}
+				steps = append(steps, jsonStep{index: index, isIndex: true})
+			}
+			rest = rest[end+1:]
+
+		default:
+			return nil, fmt.Errorf("ugucensor: unexpected %q in JSON path %q", rest[0], path)
+		}
+	}
+	return steps, nil
+}
+
+// jsonCensor copies a JSON document from r to w byte by byte, replacing
+// the selected string values that were censored.
+type jsonCensor struct {
+	c         *Censor
+	lang      string
+	selectors [][]jsonStep
+
+	r      *bufio.Reader
+	w      *bufio.Writer
+	offset int
+
+	path    []jsonElem
+	changed []string
+}
+
+func (jc *jsonCensor) document() error {
+	if err := jc.value(); err != nil {
+		return err
+	}
+	if err := jc.space(); err != nil {
+		return err
+	}
+	if _, err := jc.r.ReadByte(); err != io.EOF {
+		return jc.syntaxError("after top-level value")
+	}
+	return nil
+}
+
+func (jc *jsonCensor) value() error {
+	if err := jc.space(); err != nil {
+		return err
+	}
+	b, err := jc.peek()
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case b == '{':
+		return jc.object()
+	case b == '[':
+		return jc.array()
+	case b == '"':
+		return jc.stringValue()
+	case b == '-' || b >= '0' && b <= '9' || b >= 'a' && b <= 'z':
+		return jc.literal()
+	}
+	return jc.syntaxError("looking for a value")
+}
+
+func (jc *jsonCensor) object() error {
+	jc.copyByte()
+	if err := jc.space(); err != nil {
+		return err
+	}
+	b, err := jc.peek()
+	if err != nil {
+		return err
+	}
+	if b == '}' {
+		jc.copyByte()
+		return nil
+	}
+
+	for {
+		if err := jc.space(); err != nil {
+			return err
+		}
+		raw, err := jc.readString()
+		if err != nil {
+			return err
+		}
+		jc.write(raw)
+		var key string
+		if err := json.Unmarshal(raw, &key); err != nil {
+			return jc.syntaxError("in object key")
+		}
+
+		if err := jc.space(); err != nil {
+			return err
+		}
+		if b, err := jc.peek(); err != nil || b != ':' {
+			return jc.syntaxError("after object key")
+		}
+		jc.copyByte()
+
+		jc.path = append(jc.path, jsonElem{key: key})
+		if err := jc.value(); err != nil {
+			return err
+		}
+		jc.path = jc.path[:len(jc.path)-1]
+
+		if done, err := jc.next('}'); done || err != nil {
+			return err
+		}
+	}
+}
+
+func (jc *jsonCensor) array() error {
+	jc.copyByte()
+	if err := jc.space(); err != nil {
+		return err
+	}
+	b, err := jc.peek()
+	if err != nil {
+		return err
+	}
+	if b == ']' {
+		jc.copyByte()
+		return nil
+	}
+
+	for i := 0; ; i++ {
+		jc.path = append(jc.path, jsonElem{index: i, isIndex: true})
+		if err := jc.value(); err != nil {
+			return err
+		}
+		jc.path = jc.path[:len(jc.path)-1]
+
+		if done, err := jc.next(']'); done || err != nil {
+			return err
+		}
+	}
+}
+
+// next copies the comma between elements, or the closing bracket after
+// the last one and reports true.
+func (jc *jsonCensor) next(closing byte) (bool, error) {
+	if err := jc.space(); err != nil {
+		return false, err
+	}
+	b, err := jc.peek()
+	if err != nil {
+		return false, err
+	}
+	switch b {
+	case ',':
+		jc.copyByte()
+		return false, nil
+	case closing:
+		jc.copyByte()
+		return true, nil
+	}
+	return false, jc.syntaxError("after element")
+}
+
+func (jc *jsonCensor) stringValue() error {
+	raw, err := jc.readString()
+	if err != nil {
+		return err
+	}
+	if !jc.selected() {
+		jc.write(raw)
+		return nil
+	}
+
+	var s string
+	if err := json.Unmarshal(raw, &s); err != nil {
+		return jc.syntaxError("in string")
+	}
+	masked, hasBad := jc.c.CensorText(s, jc.lang)
+	if !hasBad && masked == s {
+		jc.write(raw)
+		return nil
+	}
+
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(masked); err != nil {
+		return err
+	}
+	jc.write(bytes.TrimSuffix(buf.Bytes(), []byte("\n")))
+	jc.changed = append(jc.changed, jc.pathString())
+	return nil
+}
+
+// readString reads a string with its quotes, leaving escapes as they are.
+func (jc *jsonCensor) readString() ([]byte, error) {
+	if b, err := jc.peek(); err != nil || b != '"' {
+		return nil, jc.syntaxError("looking for a string")
+	}
+	raw := []byte{jc.readByte()}
+	for escaped := false; ; {
+		b, err := jc.r.ReadByte()
+		if err != nil {
+			return nil, jc.syntaxError("in string")
+		}
+		jc.offset++
+		raw = append(raw, b)
+		switch {
+		case escaped:
+			escaped = false
+		case b == '\\':
+			escaped = true
+		case b == '"':
+			return raw, nil
+		}
+	}
+}
+
+// literal copies a number, true, false or null.
+func (jc *jsonCensor) literal() error {
+	start := jc.offset
+	var lit []byte
+	for {
+		b, err := jc.r.ReadByte()
+		if err != nil && err != io.EOF {
+			return err
+		}
+		if err == io.EOF || !(b == '-' || b == '+' || b == '.' || b >= '0' && b <= '9' || b >= 'a' && b <= 'z' || b >= 'A' && b <= 'Z') {
+			if err == nil {
+				jc.r.UnreadByte()
+			}
+			break
+		}
+		jc.offset++
+		lit = append(lit, b)
+	}
+	if !json.Valid(lit) {
+		jc.offset = start
+		return jc.syntaxError(fmt.Sprintf("in literal %q", lit))
+	}
+	jc.write(lit)
+	return nil
+}
+
+// space copies whitespace.
+func (jc *jsonCensor) space() error {
+	for {
+		b, err := jc.r.ReadByte()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if b != ' ' && b != '\t' && b != '\n' && b != '\r' {
+			return jc.r.UnreadByte()
+		}
+		jc.offset++
+		jc.w.WriteByte(b)
+	}
+}
+
+func (jc *jsonCensor) peek() (byte, error) {
+	b, err := jc.r.Peek(1)
+	if err == io.EOF {
+		return 0, jc.syntaxError("unexpected end of input")
+	}
+	if err != nil {
+		return 0, err
+	}
+	return b[0], nil
+}
+
+func (jc *jsonCensor) readByte() byte {
+	b, _ := jc.r.ReadByte()
+	jc.offset++
+	return b
+}
+
+func (jc *jsonCensor) copyByte() {
+	jc.w.WriteByte(jc.readByte())
+}
+
+func (jc *jsonCensor) write(b []byte) {
+	jc.w.Write(b)
+}
+
+func (jc *jsonCensor) syntaxError(context string) error {
+	return fmt.Errorf("ugucensor: invalid JSON at offset %d: %s", jc.offset, context)
+}
+
+// selected reports whether the current path is selected by any selector.
+func (jc *jsonCensor) selected() bool {
+	for _, selector := range jc.selectors {
+		if len(selector) != len(jc.path) {
+			continue
+		}
+		matched := true
+		for i, step := range selector {
+			elem := jc.path[i]
+			if step.any {
+				// "*" is any key or element, whichever way it is written
+				continue
+			}
+			if step.isIndex != elem.isIndex {
+				matched = false
+				break
+			}
+			if elem.isIndex && step.index != elem.index || !elem.isIndex && step.key != elem.key {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			return true
+		}
+	}
+	return false
+}
+
+// pathString formats the current path like "$.comments[0].body".
+func (jc *jsonCensor) pathString() string {
+	var sb strings.Builder
+	sb.WriteByte('$')
+	for _, elem := range jc.path {
+		switch {
+		case elem.isIndex:
+			fmt.Fprintf(&sb, "[%d]", elem.index)
+		case isJSONPathIdent(elem.key):
+			sb.WriteByte('.')
+			sb.WriteString(elem.key)
+		default:
+			sb.WriteByte('[')
+			sb.WriteString(strconv.Quote(elem.key))
+			sb.WriteByte(']')
+		}
+	}
+	return sb.String()
+}
+
+func isJSONPathIdent(key string) bool {
+	if key == "" || key == "*" {
+		return false
+	}
+	for _, ch := range key {
+		if !unicode.IsLetter(ch) && !unicode.IsDigit(ch) && ch != '_' && ch != '-' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,104 @@
+package ugucensor
+
+import (
+	"errors"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+func TestCensor_CensorJSON(t *testing.T) {
+	c := NewCensor()
+	c.AddWords([]string{"игра", "яблоко"}, "ru")
+
+	f := func(src string, paths []string, expected string, expectedChanged ...string) {
+		t.Helper()
+
+		var out strings.Builder
+		changed, err := c.CensorJSON(strings.NewReader(src), &out, "ru", paths...)
+		if err != nil {
+			t.Fatalf("CensorJSON(%q) error: %v", src, err)
+		}
+		if got := out.String(); got != expected || !reflect.DeepEqual(changed, expectedChanged) {
+			t.Errorf("CensorJSON(%q, %q) = %q, %q; want %q, %q", src, paths, got, changed, expected, expectedChanged)
+		}
+	}
+
+	t.Run("selected fields", func(t *testing.T) {
+		f(`{"id": "игра", "bio": "игра"}`, []string{"$.bio"},
+			`{"id": "игра", "bio": "****"}`, "$.bio")
+		f(`{"user": {"bio": "игра", "url": "/игра"}}`, []string{"$.user.bio"},
+			`{"user": {"bio": "****", "url": "/игра"}}`, "$.user.bio")
+		f(`"игра"`, []string{"$"}, `"****"`, "$")
+	})
+
+	t.Run("arrays", func(t *testing.T) {
+		src := `{"comments": [{"id": 1, "body": "игра"}, {"id": 2, "body": "мир"}, {"id": 3, "body": "яблоко"}]}`
+		f(src, []string{"$.comments[*].body"},
+			`{"comments": [{"id": 1, "body": "****"}, {"id": 2, "body": "мир"}, {"id": 3, "body": "******"}]}`,
+			"$.comments[0].body", "$.comments[2].body")
+		f(src, []string{"$.comments[2].body"},
+			`{"comments": [{"id": 1, "body": "игра"}, {"id": 2, "body": "мир"}, {"id": 3, "body": "******"}]}`,
+			"$.comments[2].body")
+		f(`["игра", ["игра"]]`, []string{"$[*]"}, `["****", ["игра"]]`, "$[0]")
+	})
+
+	t.Run("any key", func(t *testing.T) {
+		f(`{"a": "игра", "b": {"c": "игра"}, "d": ["игра"]}`, []string{"$.*"},
+			`{"a": "****", "b": {"c": "игра"}, "d": ["игра"]}`, "$.a")
+		f(`{"a": ["игра"], "b": {"c": "игра"}}`, []string{"$.*.*"},
+			`{"a": ["****"], "b": {"c": "****"}}`, "$.a[0]", "$.b.c")
+		f(`["игра", {"a": "игра"}]`, []string{"$[*]", "$[*][*]"}, `["****", {"a": "****"}]`, "$[0]", "$[1].a")
+	})
+
+	t.Run("quoted keys", func(t *testing.T) {
+		f(`{"user name": "игра"}`, []string{`$["user name"]`}, `{"user name": "****"}`, `$["user name"]`)
+	})
+
+	t.Run("formatting is kept", func(t *testing.T) {
+		src := "{\n  \"b\" : \"\\u0438гра <b>\",\n  \"a\":[ 1.5e3, true, null ]\n}\n"
+		f(src, []string{"$.b"}, "{\n  \"b\" : \"**** <b>\",\n  \"a\":[ 1.5e3, true, null ]\n}\n", "$.b")
+		f(`{"b": "мир"}`, []string{"$.b"}, `{"b": "мир"}`)
+	})
+
+	t.Run("non-string values", func(t *testing.T) {
+		f(`{"bio": {"text": "игра"}, "n": 1}`, []string{"$.bio", "$.n"}, `{"bio": {"text": "игра"}, "n": 1}`)
+	})
+}
+
+func TestCensor_CensorJSON_Errors(t *testing.T) {
+	c := NewCensor()
+	c.AddWord("игра", "ru")
+
+	f := func(src string, paths ...string) {
+		t.Helper()
+
+		var out strings.Builder
+		if _, err := c.CensorJSON(strings.NewReader(src), &out, "ru", paths...); err == nil {
+			t.Errorf("CensorJSON(%q, %q) = nil error; want an error", src, paths)
+		}
+	}
+
+	f(`{"a": "игра"`, "$.a")
+	f(`{"a" "игра"}`, "$.a")
+	f(`{"a": tru}`, "$.a")
+	f(`{"a": 1} 2`, "$.a")
+	f(`{}`, "a")
+	f(`{}`, "$[x]")
+	f(`{}`, "$[1")
+	f(`{`, "$.a")
+	f(`[`, "$[0]")
+
+	t.Run("read errors", func(t *testing.T) {
+		errRead := errors.New("read failed")
+		for _, src := range []string{"{", "[", `{"a": [`} {
+			r := io.MultiReader(strings.NewReader(src), iotest.ErrReader(errRead))
+			var out strings.Builder
+			if _, err := c.CensorJSON(r, &out, "ru", "$.a"); !errors.Is(err, errRead) {
+				t.Errorf("CensorJSON(%q and a failing read) error = %v; want %v", src, err, errRead)
+			}
+		}
+	})
+}
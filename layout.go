@@ -0,0 +1,133 @@
+package ugucensor
+
+import "unicode"
+
+// keyboardLayouts maps a language to the keys of its standard keyboard
+// layout and the characters the same keys produce on the US QWERTY layout.
+var keyboardLayouts = map[string]struct {
+	keys   string
+	qwerty string
+}{
+	"ru": {
+		keys:   "йцукенгшщзхъфывапролджэячсмитьбюёХЪЖЭБЮЁ",
+		qwerty: "qwertyuiop[]asdfghjkl;'zxcvbnm,.`{}:\"<>~",
+	},
+	"uk": {
+		keys:   "йцукенгшщзхїфівапролджєячсмитьбюХЇЖЄБЮ",
+		qwerty: "qwertyuiop[]asdfghjkl;'zxcvbnm,.{}:\"<>",
+	},
+}
+
+// layoutTables holds, for every language, the mapping of characters typed
+// with the wrong layout active to the characters intended: QWERTY to the
+// national layout for languages in keyboardLayouts, and the Russian layout
+// to QWERTY for English.
+var layoutTables = newLayoutTables()
+
+func newLayoutTables() map[string]map[rune]rune {
+	tables := make(map[string]map[rune]rune)
+	for lang, layout := range keyboardLayouts {
+		keys, qwerty := []rune(layout.keys), []rune(layout.qwerty)
+		table := make(map[rune]rune, len(keys))
+		for i := range keys {
+			table[qwerty[i]] = keys[i]
+		}
+		tables[lang] = table
+	}
+
+	ru := tables["ru"]
+	en := make(map[rune]rune, len(ru))
+	for latin, cyrillic := range ru {
+		en[cyrillic] = latin
+	}
+	tables["en"] = en
+
+	return tables
+}
+
+// findLayoutMatches finds words typed with the wrong keyboard layout
+// active, like "buhf" for "игра", and masks the original word.
+func (c *Censor) findLayoutMatches(runes []rune, lang string, found []Match) []Match {
+	table, ok := layoutTables[lang]
+	if !ok {
+		return nil
+	}
+
+	folds := c.folds[lang]
+	var matches []Match
+	for _, tok := range uncoveredTokens(layoutTokens(runes, table), found) {
+		// keys like "`" and ";" type letters too: "`krf" is "ёлка"
+		lead := tok.start
+		for lead > 0 && !unicode.IsLetter(runes[lead-1]) && unicode.IsLetter(table[runes[lead-1]]) {
+			lead--
+		}
+
+		mapped := make([]rune, 0, tok.end-lead)
+		for _, ch := range runes[lead:tok.end] {
+			ch = unicode.ToLower(table[ch])
+			if folded, ok := folds[ch]; ok {
+				ch = folded
+			}
+			mapped = append(mapped, ch)
+		}
+
+		for start := lead; start <= tok.start; start++ {
+			if m, ok := c.matchLayoutWord(runes, mapped[start-lead:], start, tok.end, lang); ok {
+				matches = append(matches, m)
+				break
+			}
+		}
+	}
+	return matches
+}
+
+// matchLayoutWord matches the mapped characters of runes[start:end],
+// dropping the keys at the end that may be plain punctuation after the
+// word, like "," and ".".
+func (c *Censor) matchLayoutWord(runes []rune, mapped []rune, start, end int, lang string) (Match, bool) {
+	for e := end; e > start; e-- {
+		if e < end && unicode.IsLetter(runes[e]) {
+			break
+		}
+		if m, ok := c.matchWord(mapped[:e-start], lang); ok {
+			m.Start, m.End = start, e
+			m.Kind = MatchLayout
+			return m, true
+		}
+	}
+	return Match{}, false
+}
+
+// layoutTokens returns the runs of characters found in the layout table
+// that start with a letter.
+func layoutTokens(runes []rune, table map[rune]rune) []token {
+	var tokens []token
+	for i := 0; i < len(runes); i++ {
+		if _, ok := table[runes[i]]; !ok || !unicode.IsLetter(runes[i]) {
+			continue
+		}
+		if i > 0 && unicode.IsLetter(runes[i-1]) {
+			// the word has letters missing from the table
+			for i < len(runes) && unicode.IsLetter(runes[i]) {
+				i++
+			}
+			continue
+		}
+		start := i
+		for i < len(runes) {
+			if _, ok := table[runes[i]]; !ok {
+				break
+			}
+			i++
+		}
+		if i < len(runes) && unicode.IsLetter(runes[i]) {
+			// the word has letters missing from the table
+			for i < len(runes) && unicode.IsLetter(runes[i]) {
+				i++
+			}
+			continue
+		}
+		tokens = append(tokens, token{start, i})
+	}
+	return tokens
+}
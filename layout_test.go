@@ -0,0 +1,46 @@
+package ugucensor
+
+import "testing"
+
+func TestCensor_CensorText_Layout(t *testing.T) {
+	c := NewCensor(WithLayoutMatching())
+	c.AddWords([]string{"игра", "яблоко", "жэх", "елка"}, "ru")
+	c.AddWords([]string{"game"}, "en")
+
+	f := func(text string, lang string, expected string, expectedCensored bool) {
+		t.Helper()
+
+		got, gotCensored := c.CensorText(text, lang)
+		if got != expected || gotCensored != expectedCensored {
+			t.Errorf("\nCensorText(%q, %q)\n\tgot : %s, %v\n\twant: %s, %v", text, lang, got, gotCensored, expected, expectedCensored)
+		}
+	}
+
+	t.Run("qwerty for russian", func(t *testing.T) {
+		f("это buhf", "ru", "это ****", true)
+		f("BUHF!", "ru", "****!", true)
+		f("buhs, buhf.", "ru", "****, ****.", true)
+		f("z,kjrj", "ru", "******", true)
+		f("Z<KJRJ", "ru", "******", true)
+		f(";'[", "ru", ";'[", false)
+		f("x ;'[ x", "ru", "x ;'[ x", false)
+	})
+
+	t.Run("letter folds", func(t *testing.T) {
+		f("`krf", "ru", "****", true)
+		f("это ~krf!", "ru", "это ****!", true)
+		f("'buhf'", "ru", "'****'", true)
+	})
+
+	t.Run("russian layout for english", func(t *testing.T) {
+		f("пфьу over", "en", "**** over", true)
+		f("ПФЬУ!", "en", "****!", true)
+	})
+
+	t.Run("no false positives", func(t *testing.T) {
+		f("bugfix", "ru", "bugfix", false)
+		f("buhfq", "ru", "buhfq", false)
+		f("buhfый", "ru", "buhfый", false)
+		f("game", "ru", "game", false)
+	})
+}
@@ -0,0 +1,150 @@
+package ugucensor
+
+import (
+	"bytes"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/extension"
+	"github.com/yuin/goldmark/text"
+)
+
+// markdownParser parses CommonMark with the GitHub extensions, so that
+// bare URLs are recognized as links and left alone.
+var markdownParser = goldmark.New(goldmark.WithExtensions(extension.GFM)).Parser()
+
+// markdownDelimiters are the inline formatting markers a word may continue
+// across: "*иг*ра", "~~иг~~ра".
+const markdownDelimiters = "*_~"
+
+// sourceEdit replaces source[start:stop] with text.
+type sourceEdit struct {
+	start int
+	stop  int
+	text  string
+}
+
+// CensorMarkdown censors Markdown text keeping its structure: the text of
+// paragraphs, headings, lists, tables, link texts and image descriptions
+// is censored, while code spans, code blocks, HTML, URLs and link
+// destinations are left alone. Words are matched across emphasis markers,
+// so "*иг*ра" is masked as "*\*\**\*\*". The asterisks are escaped to keep
+// them from being read as emphasis.
+func (c *Censor) CensorMarkdown(src string, lang string) (string, bool) {
+	source := []byte(src)
+	doc := markdownParser.Parse(text.NewReader(source))
+
+	var edits []sourceEdit
+	ast.Walk(doc, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+		if !entering || n.Type() != ast.TypeBlock {
+			return ast.WalkContinue, nil
+		}
+		if first := n.FirstChild(); first == nil || first.Type() != ast.TypeInline {
+			return ast.WalkContinue, nil
+		}
+		edits = append(edits, c.censorMarkdownInline(n, source, lang)...)
+		return ast.WalkSkipChildren, nil
+	})
+	if len(edits) == 0 {
+		return src, false
+	}
+
+	sort.Slice(edits, func(i, j int) bool { return edits[i].start < edits[j].start })
+	var result strings.Builder
+	prev := 0
+	for _, e := range edits {
+		result.Write(source[prev:e.start])
+		result.WriteString(e.text)
+		prev = e.stop
+	}
+	result.Write(source[prev:])
+	return result.String(), true
+}
+
+// censorMarkdownInline censors the text of the inline content of a block
+// as a whole and returns the edits masking the matched words.
+func (c *Censor) censorMarkdownInline(block ast.Node, source []byte, lang string) []sourceEdit {
+	var (
+		segments []text.Segment
+		afterURL bool
+	)
+	ast.Walk(block, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+		if !entering {
+			return ast.WalkContinue, nil
+		}
+		switch n := n.(type) {
+		case *ast.AutoLink:
+			afterURL = true
+			return ast.WalkSkipChildren, nil
+		case *ast.CodeSpan, *ast.RawHTML:
+			return ast.WalkSkipChildren, nil
+		case *ast.Text:
+			seg := n.Segment
+			if afterURL && seg.Start > 0 && source[seg.Start-1] != '>' {
+				// bare URLs are recognized up to the first non-ASCII
+				// character, the rest of them is text
+				seg = seg.WithStart(seg.Start + urlTailLen(seg.Value(source)))
+			}
+			afterURL = false
+			segments = append(segments, seg)
+		}
+		return ast.WalkContinue, nil
+	})
+
+	// text segments are joined directly when only emphasis markers lie
+	// between them, and separated with a space otherwise
+	var runes []rune
+	starts := make([]int, len(segments))
+	for i, seg := range segments {
+		if i > 0 {
+			gap := source[segments[i-1].Stop:seg.Start]
+			if len(bytes.Trim(gap, markdownDelimiters)) > 0 {
+				runes = append(runes, ' ')
+			}
+		}
+		starts[i] = len(runes)
+		runes = append(runes, []rune(string(seg.Value(source)))...)
+	}
+
+	matches := c.findMatches(runes, lang)
+	if len(matches) == 0 {
+		return nil
+	}
+
+	var edits []sourceEdit
+	for i, seg := range segments {
+		value := seg.Value(source)
+		start, end := starts[i], starts[i]+utf8.RuneCount(value)
+		for _, m := range clipMatches(matches, start, end) {
+			masked := runes[start+m.Start : start+m.End]
+			edits = append(edits, sourceEdit{
+				start: seg.Start + runeOffset(value, m.Start),
+				stop:  seg.Start + runeOffset(value, m.End),
+				text:  strings.Repeat(`\*`, visibleLen(masked)),
+			})
+		}
+	}
+	return edits
+}
+
+// urlTailLen returns the length of the text up to the first whitespace.
+func urlTailLen(b []byte) int {
+	if i := bytes.IndexFunc(b, unicode.IsSpace); i >= 0 {
+		return i
+	}
+	return len(b)
+}
+
+// runeOffset returns the byte offset of the n-th rune of b.
+func runeOffset(b []byte, n int) int {
+	offset := 0
+	for ; n > 0; n-- {
+		_, size := utf8.DecodeRune(b[offset:])
+		offset += size
+	}
+	return offset
+}
@@ -0,0 +1,53 @@
+package ugucensor
+
+import "testing"
+
+func TestCensor_CensorMarkdown(t *testing.T) {
+	c := NewCensor()
+	c.AddWords([]string{"игра", "яблоко"}, "ru")
+
+	f := func(src string, expected string, expectedCensored bool) {
+		t.Helper()
+
+		got, gotCensored := c.CensorMarkdown(src, "ru")
+		if got != expected || gotCensored != expectedCensored {
+			t.Errorf("CensorMarkdown(%q) = %q, %v; want %q, %v", src, got, gotCensored, expected, expectedCensored)
+		}
+	}
+
+	t.Run("plain text", func(t *testing.T) {
+		f("хорошая игра", `хорошая \*\*\*\*`, true)
+		f("# Игра\n\n- яблоко\n- мир\n", "# \\*\\*\\*\\*\n\n- \\*\\*\\*\\*\\*\\*\n- мир\n", true)
+		f("привет, мир", "привет, мир", false)
+	})
+
+	t.Run("code is skipped", func(t *testing.T) {
+		f("`игра` и игра", "`игра` и \\*\\*\\*\\*", true)
+		f("```\nигра\n```\n", "```\nигра\n```\n", false)
+		f("    игра\n", "    игра\n", false)
+		f("<span title=\"игра\">мир</span>", "<span title=\"игра\">мир</span>", false)
+	})
+
+	t.Run("links", func(t *testing.T) {
+		f("[игра](https://example.com/игра)", "[\\*\\*\\*\\*](https://example.com/игра)", true)
+		f("![игра](игра.png)", "![\\*\\*\\*\\*](игра.png)", true)
+		f("see https://example.com/игра", "see https://example.com/игра", false)
+		f("https://example.com/игра игра", "https://example.com/игра \\*\\*\\*\\*", true)
+		f("<https://игра.рф>", "<https://игра.рф>", false)
+	})
+
+	t.Run("emphasis", func(t *testing.T) {
+		f("*иг*ра", "*\\*\\**\\*\\*", true)
+		f("**игра** и ~~яблоко~~", "**\\*\\*\\*\\*** и ~~\\*\\*\\*\\*\\*\\*~~", true)
+		f("*мир*игра", "*мир*игра", false)
+	})
+
+	t.Run("tables", func(t *testing.T) {
+		f("| a | b |\n|---|---|\n| игра | мир |\n", "| a | b |\n|---|---|\n| \\*\\*\\*\\* | мир |\n", true)
+	})
+
+	t.Run("line breaks", func(t *testing.T) {
+		f("игра\nмир", "\\*\\*\\*\\*\nмир", true)
+		f("мир\nигра", "мир\n\\*\\*\\*\\*", true)
+	})
+}
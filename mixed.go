@@ -0,0 +1,113 @@
+package ugucensor
+
+import (
+	"sort"
+	"unicode"
+)
+
+// langScripts maps languages to the script their dictionaries are written
+// in. Languages missing here are checked against words of any script.
+var langScripts = map[string]*unicode.RangeTable{
+	"be": unicode.Cyrillic,
+	"bg": unicode.Cyrillic,
+	"kk": unicode.Cyrillic,
+	"mk": unicode.Cyrillic,
+	"ru": unicode.Cyrillic,
+	"sr": unicode.Cyrillic,
+	"uk": unicode.Cyrillic,
+
+	"cs": unicode.Latin,
+	"da": unicode.Latin,
+	"de": unicode.Latin,
+	"en": unicode.Latin,
+	"es": unicode.Latin,
+	"fi": unicode.Latin,
+	"fr": unicode.Latin,
+	"it": unicode.Latin,
+	"nl": unicode.Latin,
+	"no": unicode.Latin,
+	"pl": unicode.Latin,
+	"pt": unicode.Latin,
+	"sv": unicode.Latin,
+	"tr": unicode.Latin,
+
+	"el": unicode.Greek,
+}
+
+// CensorMixed censors text written in several languages at once, like
+// "this игра is trash".
+//
+// Every word is routed by its script to the candidate languages written in
+// that script and checked with their dictionaries and stemmers. The
+// candidates are langs, or all languages with dictionaries if langs is
+// empty.
+func (c *Censor) CensorMixed(text string, langs ...string) (string, bool) {
+	runes := []rune(text)
+
+	matches := c.findMixedMatches(runes, langs)
+	if len(matches) == 0 && !c.stripInvisible {
+		return text, false
+	}
+
+	return c.maskMatches(runes, matches), len(matches) > 0
+}
+
+func (c *Censor) findMixedMatches(runes []rune, langs []string) []Match {
+	if len(langs) == 0 {
+		for lang := range c.dicts {
+			langs = append(langs, lang)
+		}
+		sort.Strings(langs)
+	}
+
+	scripts := make(map[*unicode.RangeTable]bool)
+	for _, ch := range runes {
+		if script := scriptOf(ch); script != nil {
+			scripts[script] = true
+		}
+	}
+
+	var matches []Match
+	for _, lang := range langs {
+		script, ok := langScripts[lang]
+		if !ok {
+			matches = append(matches, c.findMatches(runes, lang)...)
+			continue
+		}
+		if !scripts[script] && !c.transliteration && !c.layoutMatching {
+			continue
+		}
+		for _, m := range c.findMatches(runes, lang) {
+			if m.Kind.crossScript() || matchScript(runes, m) == script {
+				matches = append(matches, m)
+			}
+		}
+	}
+	return mergeMatches(matches)
+}
+
+func scriptOf(ch rune) *unicode.RangeTable {
+	for _, script := range []*unicode.RangeTable{unicode.Latin, unicode.Cyrillic, unicode.Greek} {
+		if unicode.Is(script, ch) {
+			return script
+		}
+	}
+	return nil
+}
+
+// matchScript returns the script of the majority of the matched letters.
+func matchScript(runes []rune, m Match) *unicode.RangeTable {
+	counts := make(map[*unicode.RangeTable]int)
+	var best *unicode.RangeTable
+	for _, ch := range runes[m.Start:m.End] {
+		script := scriptOf(ch)
+		if script == nil {
+			continue
+		}
+		counts[script]++
+		if best == nil || counts[script] > counts[best] {
+			best = script
+		}
+	}
+	return best
+}
@@ -0,0 +1,44 @@
+package ugucensor
+
+import "testing"
+
+func TestCensor_CensorMixed(t *testing.T) {
+	c := NewCensor()
+	c.AddWords([]string{"игра", "яблоко"}, "ru")
+	c.AddWords([]string{"trash", "game"}, "en")
+	c.AddWords([]string{"гра"}, "uk")
+
+	f := func(text string, langs []string, expected string, expectedCensored bool) {
+		t.Helper()
+
+		got, gotCensored := c.CensorMixed(text, langs...)
+		if got != expected || gotCensored != expectedCensored {
+			t.Errorf("\nCensorMixed(%q, %q)\n\tgot : %s, %v\n\twant: %s, %v", text, langs, got, gotCensored, expected, expectedCensored)
+		}
+	}
+
+	t.Run("all languages", func(t *testing.T) {
+		f("", nil, "", false)
+		f("clean text, чистый текст", nil, "clean text, чистый текст", false)
+		f("this игра is trash", nil, "this **** is *****", true)
+		f("game, игры, гра и яблоки", nil, "****, ****, *** и ******", true)
+	})
+
+	t.Run("configured languages", func(t *testing.T) {
+		f("this игра is trash", []string{"ru"}, "this **** is trash", true)
+		f("this игра is trash", []string{"en", "ru"}, "this **** is *****", true)
+		f("this игра is trash", []string{"uk"}, "this игра is trash", false)
+		f("this игра is trash", []string{"de"}, "this игра is trash", false)
+	})
+
+	t.Run("transliteration", func(t *testing.T) {
+		c := NewCensor(WithTransliteration())
+		c.AddWords([]string{"игра"}, "ru")
+		c.AddWords([]string{"trash"}, "en")
+
+		got, _ := c.CensorMixed("this igra is trash")
+		if got != "this **** is *****" {
+			t.Errorf("CensorMixed(%q) = %q; want %q", "this igra is trash", got, "this **** is *****")
+		}
+	})
+}
@@ -0,0 +1,250 @@
+package ugucensor
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// paradigm returns the normalized word followed by its inflected forms
+// generated from the built-in suffix tables for the language. Languages
+// without tables yield the word itself. fleetingVowel marks a noun losing
+// its last vowel in the other forms, see FleetingVowel.
+//
+// The tables deliberately overgenerate: a few forms that do not exist in
+// the language are cheaper than a missed real one.
+func paradigm(word string, lang string, fleetingVowel bool) []string {
+	var forms []string
+	switch lang {
+	case "ru":
+		forms = ruParadigm(word, fleetingVowel)
+	case "en":
+		forms = enParadigm(word)
+	}
+
+	return uniqueForms(append([]string{word}, forms...))
+}
+
+func uniqueForms(forms []string) []string {
+	seen := make(map[string]bool, len(forms))
+	result := forms[:0]
+	for _, form := range forms {
+		if form == "" || seen[form] {
+			continue
+		}
+		seen[form] = true
+		result = append(result, form)
+	}
+	return result
+}
+
+func withEndings(stem string, endings ...string) []string {
+	forms := make([]string, 0, len(endings))
+	for _, ending := range endings {
+		forms = append(forms, stem+ending)
+	}
+	return forms
+}
+
+var (
+	// ruNounEndings maps a nominative singular ending to the endings of the
+	// whole paradigm (cases and numbers).
+	ruNounEndings = []struct {
+		suffix  string
+		endings []string
+	}{
+		{"ие", []string{"ие", "ия", "ию", "ием", "ии", "ий", "иям", "иями", "иях"}},
+		{"ия", []string{"ия", "ии", "ию", "ией", "иею", "ий", "иям", "иями", "иях"}},
+		{"а", []string{"а", "ы", "и", "е", "у", "ой", "ою", "ей", "ею", "", "ам", "ами", "ах"}},
+		{"я", []string{"я", "и", "е", "ю", "ей", "ею", "ь", "й", "ям", "ями", "ях"}},
+		{"о", []string{"о", "а", "у", "ом", "е", "ы", "и", "", "ов", "ам", "ами", "ах"}},
+		{"е", []string{"е", "я", "ю", "ем", "и", "ей", "ям", "ями", "ях"}},
+		{"ь", []string{"ь", "я", "ю", "ем", "е", "и", "ью", "ей", "ям", "ями", "ях", "ам", "ами", "ах"}},
+		{"й", []string{"й", "я", "ю", "ем", "е", "и", "ев", "ям", "ями", "ях"}},
+	}
+
+	ruAdjectiveEndings = []string{
+		"ый", "ий", "ой", "ого", "его", "ому", "ему", "ым", "им", "ом", "ем",
+		"ая", "яя", "ую", "юю", "ое", "ее", "ей",
+		"ые", "ие", "ых", "их", "ыми", "ими",
+	}
+
+	ruPastEndings = []string{"л", "ла", "ло", "ли"}
+)
+
+func ruParadigm(word string, fleetingVowel bool) []string {
+	if base, ok := strings.CutSuffix(word, "ся"); ok && isRuVerb(base) {
+		return ruReflexive(ruVerbParadigm(base))
+	}
+	if base, ok := strings.CutSuffix(word, "сь"); ok && isRuVerb(base) {
+		return ruReflexive(ruVerbParadigm(base))
+	}
+	if isRuVerb(word) {
+		return ruVerbParadigm(word)
+	}
+
+	var forms []string
+	// adjectives and nouns like "герой" share endings, so both are generated
+	for _, suffix := range []string{"ый", "ий", "ой"} {
+		if stem, ok := strings.CutSuffix(word, suffix); ok && len([]rune(stem)) > 2 {
+			forms = withEndings(stem, ruAdjectiveEndings...)
+			break
+		}
+	}
+
+	for _, class := range ruNounEndings {
+		if stem, ok := strings.CutSuffix(word, class.suffix); ok {
+			return append(forms, withEndings(stem, class.endings...)...)
+		}
+	}
+
+	forms = append(forms, word)
+	stem := word
+	if runes := []rune(word); fleetingVowel && len(runes) > 2 && isRuVowel(runes[len(runes)-2]) {
+		// кусок -> куска, отец -> отца
+		stem = string(runes[:len(runes)-2]) + string(runes[len(runes)-1])
+	}
+	return append(forms, withEndings(stem, ruConsonantEndings(stem)...)...)
+}
+
+// ruConsonantEndings returns the endings of a masculine noun with the stem
+// ending in a consonant, other than the nominative singular: the spelling
+// rules pick "и" after "к" (игроки), "ей" after "ш" (ножей) and so on.
+func ruConsonantEndings(stem string) []string {
+	endings := []string{"а", "у", "е", "ам", "ами", "ах"}
+
+	last, _ := utf8.DecodeLastRuneInString(stem)
+	switch {
+	case strings.ContainsRune("жшчщ", last):
+		endings = append(endings, "ом", "ем", "и", "ей")
+	case last == 'ц':
+		endings = append(endings, "ом", "ем", "ы", "ов", "ев")
+	case strings.ContainsRune("гкх", last):
+		endings = append(endings, "ом", "и", "ов")
+	default:
+		endings = append(endings, "ом", "ы", "ов")
+	}
+	return endings
+}
+
+func isRuVerb(word string) bool {
+	// the ending alone, like "ти" left of "тися", is not a verb
+	return utf8.RuneCountInString(word) > 2 &&
+		(strings.HasSuffix(word, "ть") || strings.HasSuffix(word, "ти") || strings.HasSuffix(word, "чь"))
+}
+
+func ruVerbParadigm(word string) []string {
+	forms := []string{word}
+
+	switch {
+	case strings.HasSuffix(word, "овать"), strings.HasSuffix(word, "евать"):
+		// рисовать -> рисую, рисуешь
+		stem := strings.TrimSuffix(strings.TrimSuffix(word, "овать"), "евать")
+		forms = append(forms, withEndings(stem, "ую", "уешь", "ует", "уем", "уете", "уют", "уй", "уйте", "уя", "ующий")...)
+		forms = append(forms, withEndings(strings.TrimSuffix(word, "ть"), ruPastEndings...)...)
+	case strings.HasSuffix(word, "ать"), strings.HasSuffix(word, "ять"), strings.HasSuffix(word, "еть"):
+		// играть -> играю, играешь (first conjugation)
+		stem := strings.TrimSuffix(word, "ть")
+		forms = append(forms, withEndings(stem, "ю", "ешь", "ет", "ем", "ете", "ют", "й", "йте", "я", "в", "вши", "ющий")...)
+		forms = append(forms, withEndings(stem, ruPastEndings...)...)
+		if strings.HasSuffix(word, "еть") {
+			// смотреть -> смотрю, смотришь (second conjugation)
+			forms = append(forms, ruSecondConjugation(strings.TrimSuffix(word, "еть"))...)
+		}
+		if strings.HasSuffix(word, "ать") {
+			// слышать -> слышу, слышишь
+			forms = append(forms, ruSecondConjugation(strings.TrimSuffix(word, "ать"))...)
+		}
+	case strings.HasSuffix(word, "ить"):
+		// говорить -> говорю, говоришь
+		stem := strings.TrimSuffix(word, "ить")
+		forms = append(forms, ruSecondConjugation(stem)...)
+		forms = append(forms, withEndings(stem+"и", ruPastEndings...)...)
+		forms = append(forms, withEndings(stem, "ите", "ив", "ивши")...)
+	case strings.HasSuffix(word, "нуть"), strings.HasSuffix(word, "уть"):
+		// тянуть -> тяну, тянешь
+		stem := strings.TrimSuffix(word, "уть")
+		forms = append(forms, withEndings(stem, "у", "ешь", "ет", "ем", "ете", "ут", "и", "ите", "ув")...)
+		forms = append(forms, withEndings(stem+"у", ruPastEndings...)...)
+		forms = append(forms, withEndings(stem, "", "ла", "ло", "ли")...)
+	default:
+		// нести, печь: only the most regular forms
+		stem := strings.TrimSuffix(strings.TrimSuffix(word, "ти"), "чь")
+		forms = append(forms, withEndings(stem, "у", "ешь", "ет", "ем", "ете", "ут", "и", "ите", "я", "", "ла", "ло", "ли")...)
+	}
+	return forms
+}
+
+func ruSecondConjugation(stem string) []string {
+	return withEndings(stem, "ю", "у", "ишь", "ит", "им", "ите", "ят", "ат", "и", "я", "а", "ящий", "ащий")
+}
+
+// ruReflexive appends the reflexive postfix to each form: "ся" after a
+// consonant, "сь" after a vowel.
+func ruReflexive(forms []string) []string {
+	result := make([]string, 0, len(forms))
+	for _, form := range forms {
+		if form == "" {
+			continue
+		}
+		if strings.HasSuffix(form, "ящий") || strings.HasSuffix(form, "ащий") ||
+			strings.HasSuffix(form, "ющий") || strings.HasSuffix(form, "ующий") {
+			result = append(result, form+"ся")
+			continue
+		}
+		runes := []rune(form)
+		if strings.ContainsRune("аеёиоуыэюя", runes[len(runes)-1]) {
+			result = append(result, form+"сь")
+		} else {
+			result = append(result, form+"ся")
+		}
+	}
+	return result
+}
+
+func enParadigm(word string) []string {
+	var forms []string
+
+	runes := []rune(word)
+	n := len(runes)
+	if n < 2 {
+		return nil
+	}
+	last := runes[n-1]
+	isVowel := func(r rune) bool { return strings.ContainsRune("aeiou", r) }
+
+	// plural and third person
+	switch {
+	case strings.HasSuffix(word, "s"), strings.HasSuffix(word, "x"), strings.HasSuffix(word, "z"),
+		strings.HasSuffix(word, "ch"), strings.HasSuffix(word, "sh"):
+		forms = append(forms, word+"es")
+	case last == 'y' && !isVowel(runes[n-2]):
+		forms = append(forms, word[:len(word)-1]+"ies")
+	default:
+		forms = append(forms, word+"s")
+	}
+
+	// past tense, participles and agent nouns
+	stem := word
+	switch {
+	case last == 'e':
+		stem = word[:len(word)-1]
+		forms = append(forms, word+"d", word+"r", word+"rs")
+	case last == 'y' && !isVowel(runes[n-2]):
+		forms = append(forms, word[:len(word)-1]+"ied", word[:len(word)-1]+"ier", word+"ing")
+	default:
+		if n >= 3 && !isVowel(last) && isVowel(runes[n-2]) && !isVowel(runes[n-3]) &&
+			!strings.ContainsRune("wxy", last) {
+			// stop -> stopped, stopping
+			doubled := word + string(last)
+			forms = append(forms, doubled+"ed", doubled+"ing", doubled+"er", doubled+"ers")
+		}
+		forms = append(forms, word+"ed", word+"er", word+"ers")
+	}
+	if last != 'y' || isVowel(runes[n-2]) {
+		forms = append(forms, stem+"ing")
+	}
+	if strings.HasSuffix(word, "ie") {
+		forms = append(forms, word[:len(word)-2]+"ying")
+	}
+	return forms
+}
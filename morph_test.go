@@ -0,0 +1,85 @@
+package ugucensor
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestParadigm(t *testing.T) {
+	f := func(word string, lang string, expected ...string) {
+		t.Helper()
+
+		got := paradigm(word, lang, false)
+		if got[0] != word {
+			t.Errorf("paradigm(%q, %q)[0] = %q; want %q", word, lang, got[0], word)
+		}
+		for _, form := range expected {
+			if !slices.Contains(got, form) {
+				t.Errorf("paradigm(%q, %q) = %v; missing %q", word, lang, got, form)
+			}
+		}
+	}
+
+	t.Run("russian nouns", func(t *testing.T) {
+		f("игра", "ru", "игры", "игре", "игру", "игрой", "игр", "играм", "играми", "играх")
+		f("игрок", "ru", "игрока", "игроку", "игроком", "игроке", "игроки", "игроков", "игроками", "игроках")
+		f("яблоко", "ru", "яблока", "яблоку", "яблоком", "яблоки", "яблок", "яблоками")
+		f("здание", "ru", "здания", "зданием", "зданий", "зданиях")
+		f("нож", "ru", "ножа", "ножом", "ножи", "ножей")
+	})
+
+	t.Run("fleeting vowel", func(t *testing.T) {
+		f := func(word string, expected ...string) {
+			t.Helper()
+
+			got := paradigm(word, "ru", true)
+			for _, form := range expected {
+				if !slices.Contains(got, form) {
+					t.Errorf("paradigm(%q, \"ru\", true) = %v; missing %q", word, got, form)
+				}
+			}
+		}
+
+		f("кусок", "куска", "куски", "кусков", "куском")
+		f("отец", "отца", "отцы", "отцов", "отцом")
+	})
+
+	t.Run("no junk forms", func(t *testing.T) {
+		got := paradigm("игрок", "ru", false)
+		for _, form := range []string{"игрка", "игрку", "игрокем", "игрокы", "игрокев"} {
+			if slices.Contains(got, form) {
+				t.Errorf("paradigm(%q, \"ru\") = %v; has %q", "игрок", got, form)
+			}
+		}
+	})
+
+	t.Run("russian verbs", func(t *testing.T) {
+		f("играть", "ru", "играю", "играешь", "играет", "играют", "играл", "играла", "играли", "играй")
+		f("говорить", "ru", "говорю", "говоришь", "говорят", "говорил", "говори")
+		f("рисовать", "ru", "рисую", "рисуешь", "рисуют", "рисовал")
+		f("играться", "ru", "играюсь", "играется", "игрался", "игралась")
+	})
+
+	t.Run("endings alone", func(t *testing.T) {
+		for _, word := range []string{"тися", "чься", "ти", "ть", "ся"} {
+			for _, form := range paradigm(word, "ru", false) {
+				if form == "" {
+					t.Errorf("paradigm(%q, \"ru\") has an empty form", word)
+				}
+			}
+		}
+	})
+
+	t.Run("english", func(t *testing.T) {
+		f("game", "en", "games", "gamed", "gaming", "gamer", "gamers")
+		f("play", "en", "plays", "played", "playing", "player")
+		f("stop", "en", "stops", "stopped", "stopping")
+		f("party", "en", "parties", "partied")
+	})
+
+	t.Run("unknown language", func(t *testing.T) {
+		if got := paradigm("spiel", "de", false); !slices.Equal(got, []string{"spiel"}) {
+			t.Errorf("paradigm(%q, %q) = %v; want [spiel]", "spiel", "de", got)
+		}
+	})
+}
@@ -0,0 +1,222 @@
+package ugucensor
+
+import (
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/rivo/uniseg"
+	"golang.org/x/text/unicode/norm"
+)
+
+// defaultFolds are the letter equivalences applied by normalization unless
+// overridden with WithLetterFolding.
+var defaultFolds = map[string]map[rune]rune{
+	"ru": {'ё': 'е'},
+}
+
+// keptLetters are the letters with diacritics that are letters of their own
+// in a language, kept when normalization strips the diacritics of the
+// others. The letter folds apply to them afterwards, so "ё" is kept and
+// then folded to "е" in Russian.
+var keptLetters = map[string]string{
+	"ru": "йё",
+	"uk": "йї",
+	"be": "йёў",
+}
+
+// keptPairs maps, for every language, the base letter and the combining
+// mark of each kept letter to the letter.
+var keptPairs = newKeptPairs()
+
+func newKeptPairs() map[string]map[[2]rune]rune {
+	pairs := make(map[string]map[[2]rune]rune)
+	for lang, letters := range keptLetters {
+		pairs[lang] = make(map[[2]rune]rune)
+		for _, letter := range letters {
+			for _, ch := range []rune{letter, unicode.ToUpper(letter)} {
+				if d := []rune(norm.NFD.String(string(ch))); len(d) == 2 {
+					pairs[lang][[2]rune{d[0], d[1]}] = ch
+				}
+			}
+		}
+	}
+	return pairs
+}
+
+// normalized is text prepared for matching.
+type normalized struct {
+	runes []rune
+	// starts and ends hold, for every normalized rune, the span of the
+	// original runes it was produced from.
+	starts []int
+	ends   []int
+	// upper tells, for every normalized rune, whether it was an uppercase
+	// letter before lowercasing.
+	upper []bool
+}
+
+// normalize prepares runes for matching: every character is decomposed
+// (NFKD), stripped of its combining marks unless it is one of the kept
+// letters of the language, recomposed (NFC), lowercased and folded with the
+// letter equivalences of the language, so "gáme" reads as "game" and "й"
+// stays "й". Invisible characters are dropped, so they do not split words.
+// Offsets of the original runes are kept to map matches back; dropped
+// runes belong to the character before them.
+func (c *Censor) normalize(runes []rune, lang string) normalized {
+	n := normalized{
+		runes:  make([]rune, 0, len(runes)),
+		starts: make([]int, 0, len(runes)),
+		ends:   make([]int, 0, len(runes)),
+		upper:  make([]bool, 0, len(runes)),
+	}
+
+	folds := c.folds[lang]
+	var (
+		buf      []byte
+		stripped []rune
+	)
+	for start := 0; start < len(runes); {
+		// a segment is a character with the combining marks and invisible
+		// characters following it
+		end := start + 1
+		for end < len(runes) && isTransparent(runes[end]) {
+			end++
+		}
+
+		segment := runes[start:end]
+		if len(segment) > 1 || segment[0] >= utf8.RuneSelf {
+			// the buffers are reused, so plain letters cost no allocation
+			buf, stripped = stripMarks(segment, lang, buf, stripped)
+			segment = stripped
+		}
+
+		for _, ch := range segment {
+			if isTransparent(ch) {
+				continue
+			}
+			n.upper = append(n.upper, unicode.IsUpper(ch))
+			ch = unicode.ToLower(ch)
+			if folded, ok := folds[ch]; ok {
+				ch = folded
+			}
+			n.runes = append(n.runes, ch)
+			n.starts = append(n.starts, start)
+			n.ends = append(n.ends, end)
+		}
+		start = end
+	}
+	return n
+}
+
+// stripMarks decomposes the segment, drops the combining marks that are not
+// part of a kept letter of the language and recomposes the rest. It returns
+// the buffers given, grown as needed, with the result in the second.
+func stripMarks(segment []rune, lang string, buf []byte, stripped []rune) ([]byte, []rune) {
+	buf = buf[:0]
+	for _, ch := range segment {
+		buf = utf8.AppendRune(buf, ch)
+	}
+	decomposed := len(buf)
+	if !norm.NFKD.IsNormal(buf) {
+		var it norm.Iter
+		it.Init(norm.NFKD, buf[:decomposed])
+		for !it.Done() {
+			buf = append(buf, it.Next()...)
+		}
+	} else {
+		buf = append(buf, buf...)
+	}
+
+	pairs := keptPairs[lang]
+	stripped = stripped[:0]
+	base := -1
+	for _, ch := range string(buf[decomposed:]) {
+		if !unicode.Is(unicode.Mn, ch) {
+			base = len(stripped)
+			stripped = append(stripped, ch)
+			continue
+		}
+		if base < 0 {
+			continue
+		}
+		if kept, ok := pairs[[2]rune{stripped[base], ch}]; ok {
+			stripped[base] = kept
+			// the other marks of the letter are dropped
+			base = -1
+		}
+	}
+
+	buf = buf[:0]
+	for _, ch := range stripped {
+		buf = utf8.AppendRune(buf, ch)
+	}
+	if !norm.NFC.IsNormal(buf) {
+		// jamo of Hangul syllables and the like
+		stripped = append(stripped[:0], []rune(norm.NFC.String(string(buf)))...)
+	}
+	return buf, stripped
+}
+
+// normalizeWord normalizes a dictionary word the same way as the text it
+// is matched against.
+func (c *Censor) normalizeWord(word string, lang string) string {
+	return string(c.normalize([]rune(word), lang).runes)
+}
+
+// original maps a match found in the normalized runes back to the original ones.
+func (n normalized) original(m Match) Match {
+	m.Start = n.starts[m.Start]
+	m.End = n.ends[m.End-1]
+	return m
+}
+
+// isTransparent reports whether the character is skipped by matching:
+// a combining mark or an invisible character.
+func isTransparent(ch rune) bool {
+	return unicode.In(ch, unicode.Mn, unicode.Me) || isInvisible(ch)
+}
+
+// isInvisible reports whether the character has no glyph of its own:
+// format characters (zero-width spaces and joiners, soft hyphen, bidi
+// marks), the combining grapheme joiner and variation selectors.
+func isInvisible(ch rune) bool {
+	return unicode.Is(unicode.Cf, ch) ||
+		ch == '\u034f' ||
+		unicode.Is(unicode.Variation_Selector, ch)
+}
+
+// visibleLen returns the number of grapheme clusters that are not
+// invisible: the number of characters a reader sees.
+func visibleLen(runes []rune) int {
+	return len(visibleGraphemes(runes))
+}
+
+// visibleGraphemes splits runes into grapheme clusters, a letter with its
+// combining marks or an emoji with its modifiers, without the invisible
+// characters outside clusters.
+func visibleGraphemes(runes []rune) [][]rune {
+	var clusters [][]rune
+	g := uniseg.NewGraphemes(string(runes))
+	for g.Next() {
+		cluster := g.Runes()
+		for _, ch := range cluster {
+			if !isInvisible(ch) {
+				clusters = append(clusters, cluster)
+				break
+			}
+		}
+	}
+	return clusters
+}
+
+func (c *Censor) setFold(lang string, from rune, to rune) {
+	if c.folds[lang] == nil {
+		c.folds[lang] = make(map[rune]rune)
+	}
+	from, to = unicode.ToLower(from), unicode.ToLower(to)
+	if from == to {
+		delete(c.folds[lang], from)
+		return
+	}
+	c.folds[lang][from] = to
+}
@@ -0,0 +1,130 @@
+package ugucensor
+
+import "testing"
+
+func TestCensor_normalize(t *testing.T) {
+	c := NewCensor()
+
+	f := func(text string, lang string, expected string, starts []int, ends []int) {
+		t.Helper()
+
+		got := c.normalize([]rune(text), lang)
+		if string(got.runes) != expected {
+			t.Errorf("normalize(%q, %q) = %q; want %q", text, lang, string(got.runes), expected)
+			return
+		}
+		for i := range starts {
+			if got.starts[i] != starts[i] || got.ends[i] != ends[i] {
+				t.Errorf("normalize(%q, %q) offsets = %v, %v; want %v, %v", text, lang, got.starts, got.ends, starts, ends)
+				return
+			}
+		}
+	}
+
+	t.Run("plain text", func(t *testing.T) {
+		f("", "ru", "", nil, nil)
+		f("Игра!", "ru", "игра!", []int{0, 1, 2, 3, 4}, []int{1, 2, 3, 4, 5})
+	})
+
+	t.Run("combining marks", func(t *testing.T) {
+		f("и\u0301гра", "ru", "игра", []int{0, 2, 3, 4}, []int{2, 3, 4, 5})
+		f("игра\u0301", "ru", "игра", []int{0, 1, 2, 3}, []int{1, 2, 3, 5})
+	})
+
+	t.Run("composition", func(t *testing.T) {
+		f("и\u0306", "ru", "й", []int{0}, []int{2})
+		f("е\u0308ж", "ru", "еж", []int{0, 2}, []int{2, 3})
+		f("ёж", "ru", "еж", []int{0, 1}, []int{1, 2})
+		f("ёж", "uk", "еж", []int{0, 1}, []int{1, 2})
+	})
+
+	t.Run("diacritics", func(t *testing.T) {
+		f("gáme", "en", "game", []int{0, 1, 2, 3}, []int{1, 2, 3, 4})
+		f("ga\u0301me", "en", "game", []int{0, 1, 3, 4}, []int{1, 3, 4, 5})
+		f("CAFÉ", "en", "cafe", []int{0, 1, 2, 3}, []int{1, 2, 3, 4})
+		f("мой", "ru", "мой", []int{0, 1, 2}, []int{1, 2, 3})
+		f("мои\u0306", "ru", "мой", []int{0, 1, 2}, []int{1, 2, 4})
+		f("її", "uk", "її", []int{0, 1}, []int{1, 2})
+		f("її", "ru", "іі", []int{0, 1}, []int{1, 2})
+		f("ў", "be", "ў", []int{0}, []int{1})
+		f("한국", "ko", "한국", []int{0, 1}, []int{1, 2})
+	})
+
+	t.Run("compatibility", func(t *testing.T) {
+		f("ＩＧＲＡ", "en", "igra", []int{0, 1, 2, 3}, []int{1, 2, 3, 4})
+		f("ﬁne", "en", "fine", []int{0, 0, 1, 2}, []int{1, 1, 2, 3})
+	})
+}
+
+func TestCensor_CensorText_Normalization(t *testing.T) {
+	c := NewCensor()
+	c.AddWords([]string{"игра", "ёлка"}, "ru")
+	c.AddWords([]string{"fine", "game", "café"}, "en")
+
+	f := func(text string, lang string, expected string) {
+		t.Helper()
+
+		if got, _ := c.CensorText(text, lang); got != expected {
+			t.Errorf("CensorText(%q, %q) = %q; want %q", text, lang, got, expected)
+		}
+	}
+
+	f("и\u0301гра", "ru", "****")
+	f("это игра\u0301!", "ru", "это ****!")
+	f("ИГРА\u0301", "ru", "****")
+	f("елка и ёлка", "ru", "**** и ****")
+	f("е\u0308лка", "ru", "****")
+	f("ＩＧＲＡ is ｆｉｎｅ", "en", "ＩＧＲＡ is ****")
+	f("ﬁne", "en", "***")
+	f("gáme and café", "en", "**** and ****")
+	f("ga\u0301me and cafe\u0301", "en", "**** and ****")
+
+	t.Run("optional folding", func(t *testing.T) {
+		c := NewCensor(WithLetterFolding("ru", 'Й', 'и'))
+		c.AddWord("мой", "ru")
+
+		f := func(text string, expected string) {
+			t.Helper()
+
+			if got, _ := c.CensorText(text, "ru"); got != expected {
+				t.Errorf("CensorText(%q, \"ru\") = %q; want %q", text, got, expected)
+			}
+		}
+
+		f("мой", "***")
+		f("мои", "***")
+		f("мои\u0306", "***")
+	})
+}
+
+func TestCensor_CensorText_Invisible(t *testing.T) {
+	c := NewCensor()
+	c.AddWords([]string{"игра", "яблоко"}, "ru")
+
+	strip := NewCensor(WithStripInvisible())
+	strip.AddWords([]string{"игра", "яблоко"}, "ru")
+
+	f := func(c *Censor, text string, expected string, expectedCensored bool) {
+		t.Helper()
+
+		got, gotCensored := c.CensorText(text, "ru")
+		if got != expected || gotCensored != expectedCensored {
+			t.Errorf("\nCensorText(%q, \"ru\")\n\tgot : %q, %v\n\twant: %q, %v", text, got, gotCensored, expected, expectedCensored)
+		}
+	}
+
+	t.Run("transparent", func(t *testing.T) {
+		f(c, "и\u200bгра", "****", true)
+		f(c, "иг\u200dра", "****", true)
+		f(c, "иг\u00adра!", "****!", true)
+		f(c, "и\u034fгра", "****", true)
+		f(c, "я\ufe0fбл\u2060око", "******", true)
+		f(c, "это \u200bигра\u200b.", "это \u200b****.", true)
+	})
+
+	t.Run("stripped", func(t *testing.T) {
+		f(strip, "и\u200bгра", "****", true)
+		f(strip, "это \u200bигра\u200b.", "это ****.", true)
+		f(strip, "чистый\u200b текст", "чистый текст", false)
+	})
+}
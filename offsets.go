@@ -0,0 +1,32 @@
+package ugucensor
+
+import "unicode/utf8"
+
+// setOffsets sets the byte and UTF-16 offsets of the matches from their
+// rune offsets in runes. The matches must be ordered by position.
+func setOffsets(runes []rune, matches []Match) {
+	var (
+		pos, bytes, units int
+	)
+	advance := func(to int) {
+		for ; pos < to; pos++ {
+			bytes += utf8.RuneLen(runes[pos])
+			units++
+			if runes[pos] >= 0x10000 {
+				// a surrogate pair
+				units++
+			}
+		}
+	}
+
+	for i := range matches {
+		m := &matches[i]
+		if m.Start < pos {
+			pos, bytes, units = 0, 0, 0
+		}
+		advance(m.Start)
+		m.ByteStart, m.UTF16Start = bytes, units
+		advance(m.End)
+		m.ByteEnd, m.UTF16End = bytes, units
+	}
+}
@@ -0,0 +1,77 @@
+package ugucensor
+
+import (
+	"testing"
+	"unicode/utf16"
+)
+
+func TestCensor_FindMatches_Offsets(t *testing.T) {
+	c := NewCensor()
+	c.AddWords([]string{"игра", "game"}, "ru")
+	c.AddWords([]string{"game"}, "en")
+
+	f := func(text string, lang string, expected ...string) {
+		t.Helper()
+
+		matches := c.FindMatches(text, lang)
+		if len(matches) != len(expected) {
+			t.Fatalf("FindMatches(%q) = %+v; want %d matches", text, matches, len(expected))
+		}
+		runes := []rune(text)
+		units := utf16.Encode(runes)
+		for i, m := range matches {
+			byRunes := string(runes[m.Start:m.End])
+			byBytes := text[m.ByteStart:m.ByteEnd]
+			byUnits := string(utf16.Decode(units[m.UTF16Start:m.UTF16End]))
+			if byRunes != expected[i] || byBytes != expected[i] || byUnits != expected[i] {
+				t.Errorf("FindMatches(%q)[%d] spans %q, %q, %q; want %q", text, i, byRunes, byBytes, byUnits, expected[i])
+			}
+		}
+	}
+
+	f("игра", "ru", "игра")
+	f("😀 игра и 👍🏽 игра", "ru", "игра", "игра")
+	f("🎮game 𝔞 game", "en", "game", "game")
+	f("и\u0301гра!", "ru", "и\u0301гра")
+}
+
+func TestCensor_CensorText_Graphemes(t *testing.T) {
+	c := NewCensor()
+	c.AddWords([]string{"игра", "яблоко"}, "ru")
+
+	f := func(text string, style MaskStyle, expected string) {
+		t.Helper()
+
+		runes := []rune(text)
+		if got := c.maskMatchesStyle(runes, c.findMatches(runes, "ru"), style); got != expected {
+			t.Errorf("maskMatchesStyle(%q, %v) = %q; want %q", text, style, got, expected)
+		}
+	}
+
+	f("и\u0301гра", MaskFull, "****")
+	f("и\u0301гра", MaskKeepFirst, "и\u0301***")
+	f("игра\u0301", MaskKeepEdges, "и**а\u0301")
+	f("и\u200bгра", MaskFull, "****")
+	f("😀 игра 👍🏽", MaskFull, "😀 **** 👍🏽")
+
+	t.Run("subtitles", func(t *testing.T) {
+		if got, _ := c.censorTagged("<i>и\u0301г</i>ра\nяблоко\u0306", "ru"); got != "<i>**</i>**\n******" {
+			t.Errorf("censorTagged() = %q", got)
+		}
+	})
+}
+
+func TestCensor_ValidateIdentifier_Offsets(t *testing.T) {
+	c := NewCensor()
+	c.AddWord("игра", "ru")
+
+	s := "😀_игр@"
+	ok, matches := c.ValidateIdentifier(s, "ru")
+	if ok || len(matches) != 1 {
+		t.Fatalf("ValidateIdentifier(%q) = %v, %+v", s, ok, matches)
+	}
+	m := matches[0]
+	if got := s[m.ByteStart:m.ByteEnd]; got != "игр@" || m.UTF16Start != 3 || m.UTF16End != 7 {
+		t.Errorf("ValidateIdentifier(%q) match spans %q, UTF-16 %d-%d", s, got, m.UTF16Start, m.UTF16End)
+	}
+}
@@ -0,0 +1,230 @@
+package ugucensor
+
+// Option configures a Censor created by NewCensor.
+type Option func(*Censor)
+
+// EntryOption configures a single dictionary entry added by AddWord.
+type EntryOption func(*entry)
+
+// WithParadigmExpansion makes AddWord expand every entry into its inflected
+// forms (cases, numbers, verb conjugations) using the built-in suffix tables
+// and insert them as exact entries instead of a single stem.
+//
+// Exact forms do not match unrelated words that merely share the stem
+// ("играция" for "игра"). Languages without suffix tables are inserted as is.
+func WithParadigmExpansion() Option {
+	return func(c *Censor) {
+		c.expandParadigms = true
+	}
+}
+
+// WithPrefixMatching enables prefix matching for every entry added to the
+// Censor, unless the entry opts out with PrefixMatching(false).
+//
+// With prefix matching a word is also censored when it consists of a
+// prefix from the language's prefix table followed by a dictionary word:
+// "поиграть", "выигрывать" for "играть". The prefix is masked as well.
+func WithPrefixMatching() Option {
+	return func(c *Censor) {
+		c.prefixMatching = true
+	}
+}
+
+// WithPrefixes replaces the prefix table used by prefix matching for the
+// language.
+func WithPrefixes(lang string, prefixes ...string) Option {
+	return func(c *Censor) {
+		c.setPrefixes(lang, prefixes)
+	}
+}
+
+// PrefixMatching opts the entry in or out of prefix matching regardless
+// of WithPrefixMatching.
+func PrefixMatching(enabled bool) EntryOption {
+	return func(e *entry) {
+		e.prefixMatching = enabled
+	}
+}
+
+// FleetingVowel marks a Russian noun whose last vowel drops out of its
+// inflected forms, like "кусок" (куска) or "отец" (отца), for
+// WithParadigmExpansion.
+func FleetingVowel() EntryOption {
+	return func(e *entry) {
+		e.fleetingVowel = true
+	}
+}
+
+// WithMinConfidence sets the minimum language detection confidence for
+// CensorAuto to trust the detected language. Defaults to 0.5.
+func WithMinConfidence(confidence float64) Option {
+	return func(c *Censor) {
+		c.minConfidence = confidence
+	}
+}
+
+// WithFallbackLanguages sets the languages CensorAuto censors the text
+// with when the language cannot be detected reliably.
+func WithFallbackLanguages(langs ...string) Option {
+	return func(c *Censor) {
+		c.fallbackLangs = langs
+	}
+}
+
+// WithLetterFolding makes the letter from equivalent to the letter to in the
+// language, both in dictionary words and in the censored text. Russian folds
+// "ё" to "е" by default; WithLetterFolding("ru", 'й', 'и') additionally folds
+// "й" to "и". Folding a letter to itself disables the equivalence.
+func WithLetterFolding(lang string, from rune, to rune) Option {
+	return func(c *Censor) {
+		c.setFold(lang, from, to)
+	}
+}
+
+// WithStripInvisible removes invisible characters (zero-width spaces and
+// joiners, soft hyphens, variation selectors) from the censored text.
+// Matching skips them regardless of this option.
+//
+// Note that emoji sequences joined with U+200D fall apart without the joiner.
+func WithStripInvisible() Option {
+	return func(c *Censor) {
+		c.stripInvisible = true
+	}
+}
+
+// EntrySeverity sets the severity of the entry. Entries are of
+// SeverityMedium by default.
+func EntrySeverity(severity Severity) EntryOption {
+	return func(e *entry) {
+		e.severity = severity
+	}
+}
+
+// EntryCategory sets the category of the entry, like "slur" or "drugs",
+// reported in the matches of the entry.
+func EntryCategory(category string) EntryOption {
+	return func(e *entry) {
+		e.category = category
+	}
+}
+
+// WithFuzzyMatching enables fuzzy matching for entries of at least minLen
+// letters: words within a small edit distance of an entry ("игрв", "ирга"
+// for "игра") are censored too. The maximum distance depends on the
+// severity of the entry, see WithFuzzyDistance, and is at most half the
+// length of the entry. Replacing a letter counts as one edit only for
+// letters on neighboring keys.
+func WithFuzzyMatching(minLen int) Option {
+	return func(c *Censor) {
+		c.fuzzyMinLen = minLen
+	}
+}
+
+// WithFuzzyDistance sets the maximum edit distance of fuzzy matches for
+// entries of the severity. Defaults are 0 for SeverityLow, 1 for
+// SeverityMedium and 2 for SeverityHigh.
+func WithFuzzyDistance(severity Severity, maxDist int) Option {
+	return func(c *Censor) {
+		c.fuzzyDistances[severity] = maxDist
+	}
+}
+
+// WithTransliteration makes words typed in Latin letters match the
+// dictionary of a language written in Cyrillic when transliterated:
+// "igra", "jabloko", "yabloko" for "игра" and "яблоко". Common informal
+// schemes are supported along with GOST.
+func WithTransliteration() Option {
+	return func(c *Censor) {
+		c.transliteration = true
+	}
+}
+
+// WithLayoutMatching makes words typed with the wrong keyboard layout
+// active match the dictionary of the language they were meant in: "buhf" is
+// "игра" typed on QWERTY, "пфьу" is "game" typed on ЙЦУКЕН. The original
+// word is masked.
+func WithLayoutMatching() Option {
+	return func(c *Censor) {
+		c.layoutMatching = true
+	}
+}
+
+// WithWildcards makes the symbols inside a word stand for a single unknown
+// letter, so that self-censored words like "и*ра" or "ябл#ко" are matched
+// when the visible letters still spell a dictionary word. Words with more
+// than maxWildcards symbols are left alone. The symbols default to "*",
+// "#" and "_".
+//
+// Such matches are of kind MatchSelfCensored.
+func WithWildcards(maxWildcards int, symbols ...rune) Option {
+	return func(c *Censor) {
+		if len(symbols) == 0 {
+			symbols = defaultWildcards
+		}
+		c.wildcards = symbols
+		c.maxWildcards = maxWildcards
+	}
+}
+
+// WithSkeletonMatching indexes the dictionary by the consonant skeleton of
+// every entry and makes words written without vowels match it: "грк" or
+// "гр*к" for "игрок". The symbols standing for vowels are the ones of
+// WithWildcards, "*", "#" and "_" by default. Only skeletons of at least
+// minLen consonants are indexed, as shorter ones are shared by too many
+// words.
+//
+// Such matches are of kind MatchSkeleton and have a lower confidence. The
+// option must be given before words are added.
+func WithSkeletonMatching(minLen int) Option {
+	return func(c *Censor) {
+		c.skeletonMinLen = minLen
+	}
+}
+
+// WithReversedMatching makes words spelled backwards match the dictionary:
+// "арги" for "игра", "околбя" for "яблоко". The reversed text goes through
+// the same stem path as the text itself.
+//
+// Such matches are of kind MatchReversed, which is Obfuscated.
+func WithReversedMatching() Option {
+	return func(c *Censor) {
+		c.reversed = true
+	}
+}
+
+// WithWordSplitting splits camelCase words like "СуперИграТоп" or
+// "superGameTop" at case changes and checks every part as a word of its
+// own. Hashtags and snake_case are split at the symbols anyway.
+//
+// Such matches are of kind MatchCompound.
+func WithWordSplitting() Option {
+	return func(c *Censor) {
+		c.wordSplitting = true
+	}
+}
+
+// WithInfixMatching makes dictionary entries of at least minLen letters
+// match anywhere inside a word, not only at its start: "суперигра",
+// "#мояигра", German compounds. As it matches inside innocent words too,
+// use it along with AllowWord.
+//
+// Such matches are of kind MatchCompound.
+func WithInfixMatching(minLen int) Option {
+	return func(c *Censor) {
+		c.infixMinLen = minLen
+	}
+}
+
+// WithHTMLAttributes makes CensorHTML censor the values of the attributes
+// along with the text, alt and title if none are given.
+func WithHTMLAttributes(names ...string) Option {
+	return func(c *Censor) {
+		if len(names) == 0 {
+			names = defaultHTMLAttributes
+		}
+		c.htmlAttributes = make(map[string]bool, len(names))
+		for _, name := range names {
+			c.htmlAttributes[name] = true
+		}
+	}
+}
@@ -0,0 +1,128 @@
+package ugucensor
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// defaultPrefixes are the prefix tables used by prefix matching unless
+// replaced with WithPrefixes.
+var defaultPrefixes = map[string][]string{
+	"ru": {
+		"без", "бес", "в", "во", "вз", "взо", "вс", "вы", "до", "за", "из", "изо", "ис",
+		"на", "над", "надо", "недо", "низ", "нис", "о", "об", "обо", "от", "ото",
+		"пере", "по", "под", "подо", "пре", "пред", "предо", "при", "про",
+		"раз", "разо", "рас", "с", "со", "у",
+	},
+}
+
+// ruSecondaryImperfective are the suffixes turning a perfective verb into an
+// imperfective one: выиграть -> выигрывать.
+var ruSecondaryImperfective = []string{"ыва", "ива"}
+
+func (c *Censor) setPrefixes(lang string, prefixes []string) {
+	table := make([][]rune, 0, len(prefixes))
+	for _, prefix := range prefixes {
+		table = append(table, []rune(strings.ToLower(prefix)))
+	}
+	// longer prefixes first, so "пере" is tried before "пе"
+	sort.SliceStable(table, func(i, j int) bool {
+		return len(table[i]) > len(table[j])
+	})
+	c.prefixes[lang] = table
+}
+
+// findPrefixedMatches finds words consisting of a prefix from the
+// language's prefix table followed by a dictionary word, at the starts
+// found by findPossibleBadWordStarts. The prefix is skipped before walking
+// the trie and included in the match.
+func (c *Censor) findPrefixedMatches(runes []rune, starts []int, lang string) []Match {
+	var matches []Match
+	for _, start := range starts {
+		for _, prefix := range c.prefixes[lang] {
+			if m, ok := c.matchPrefixed(runes, start, prefix, lang); ok {
+				matches = append(matches, m)
+				break
+			}
+		}
+	}
+	return matches
+}
+
+// hasPrefixAt reports whether a prefix of the language's prefix table
+// followed by a letter starts at start.
+func (c *Censor) hasPrefixAt(runes []rune, start int, lang string) bool {
+	for _, prefix := range c.prefixes[lang] {
+		rest := start + len(prefix)
+		if rest < len(runes) && unicode.IsLetter(runes[rest]) && hasRunesAt(runes, start, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *Censor) matchPrefixed(runes []rune, start int, prefix []rune, lang string) (Match, bool) {
+	rest := start + len(prefix)
+	if rest >= len(runes) || !unicode.IsLetter(runes[rest]) {
+		return Match{}, false
+	}
+	for j, ch := range prefix {
+		if unicode.ToLower(runes[start+j]) != ch {
+			return Match{}, false
+		}
+	}
+
+	candidates := [][]rune{runes}
+	if unicode.ToLower(runes[rest]) == 'ы' && !isRuVowel(prefix[len(prefix)-1]) {
+		// и turns into ы after a prefix ending with a consonant: сыграть, разыграть
+		alt := append([]rune(nil), runes...)
+		alt[rest] = 'и'
+		candidates = append(candidates, alt)
+	}
+
+	for _, candidate := range candidates {
+		for _, wb := range c.findPossibleBadWordBounds(candidate, []int{rest}, lang) {
+			e, ok := c.lookupPrefixed(wb.Word, wb.BadPart, lang)
+			if !ok || !e.prefixMatching {
+				continue
+			}
+			return Match{
+				Start:      start,
+				End:        wb.End,
+				Word:       string(prefix) + wb.Word,
+				Entry:      e.word,
+				Prefix:     string(prefix),
+				Severity:   e.severity,
+				Category:   e.category,
+				Kind:       MatchPrefixed,
+				Confidence: 1,
+			}, true
+		}
+	}
+	return Match{}, false
+}
+
+// lookupPrefixed is lookup that also accepts verbs with a secondary
+// imperfective suffix, which only appear with a prefix.
+func (c *Censor) lookupPrefixed(word string, badPart string, lang string) (*entry, bool) {
+	if e, ok := c.lookup(word, badPart, lang); ok {
+		return e, true
+	}
+
+	stemmer := c.stemmers[lang]
+	if lang != "ru" || stemmer == nil {
+		return nil, false
+	}
+	stem := stemmer.Stem(word)
+	for _, suffix := range ruSecondaryImperfective {
+		if base, ok := strings.CutSuffix(stem, suffix); ok && c.dicts[lang].Search(base) {
+			return c.entries[lang][base], true
+		}
+	}
+	return nil, false
+}
+
+func isRuVowel(ch rune) bool {
+	return strings.ContainsRune("аеёиоуыэюя", ch)
+}
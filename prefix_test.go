@@ -0,0 +1,84 @@
+package ugucensor
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestCensor_CensorText_PrefixMatching(t *testing.T) {
+	c := NewCensor(WithPrefixMatching())
+	c.AddWords([]string{"игра", "играть", "игрок"}, "ru")
+	c.AddWord("яблоко", "ru", PrefixMatching(false))
+
+	f := func(text string, expected string, expectedCensored bool) {
+		t.Helper()
+
+		got, gotCensored := c.CensorText(text, "ru")
+		if got != expected || gotCensored != expectedCensored {
+			t.Errorf("\nCensorText(%q, \"ru\")\n\tgot : %s, %v\n\twant: %s, %v", text, got, gotCensored, expected, expectedCensored)
+		}
+	}
+
+	t.Run("prefixed words", func(t *testing.T) {
+		f("давай поиграем", "давай ********", true)
+		f("Поиграть бы.", "******** бы.", true)
+		f("они заиграли", "они ********", true)
+		f("мы выигрываем", "мы **********", true)
+		f("выиграть", "********", true)
+		f("отыграть и сыграть", "******** и *******", true)
+		f("разыграли", "*********", true)
+	})
+
+	t.Run("unprefixed words still match", func(t *testing.T) {
+		f("игра, игрок", "****, *****", true)
+	})
+
+	t.Run("entry opted out", func(t *testing.T) {
+		f("яблоко", "******", true)
+		f("пояблоко", "пояблоко", false)
+	})
+
+	t.Run("no false positives", func(t *testing.T) {
+		f("по играм", "по *****", true)
+		f("награда", "награда", false)
+		f("приграничный", "приграничный", false)
+	})
+}
+
+func TestCensor_CensorText_PrefixMatchingOptIn(t *testing.T) {
+	c := NewCensor(WithPrefixes("ru", "за"))
+	c.AddWord("играть", "ru")
+	c.AddWord("яблоко", "ru", PrefixMatching(true))
+
+	f := func(text string, expected string) {
+		t.Helper()
+
+		if got, _ := c.CensorText(text, "ru"); got != expected {
+			t.Errorf("CensorText(%q, \"ru\") = %q; want %q", text, got, expected)
+		}
+	}
+
+	f("заиграть", "заиграть")
+	f("заяблоко", "********")
+	f("пояблоко", "пояблоко")
+}
+
+func TestCensor_findPossibleBadWordStarts_Prefixed(t *testing.T) {
+	f := func(c *Censor, text string, expected []int) {
+		t.Helper()
+
+		_, got := c.findPossibleBadWordStarts([]rune(text), "ru")
+		if !slices.Equal(got, expected) {
+			t.Errorf("findPossibleBadWordStarts(%q) prefixed starts = %v; want %v", text, got, expected)
+		}
+	}
+
+	c := NewCensor(WithPrefixMatching())
+	c.AddWord("играть", "ru")
+	f(c, "мы поиграли и выиграли", []int{3, 14})
+	f(c, "о", nil)
+
+	plain := NewCensor()
+	plain.AddWord("играть", "ru")
+	f(plain, "мы поиграли", nil)
+}
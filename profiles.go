@@ -0,0 +1,42 @@
+package ugucensor
+
+// Character trigram profiles used by DetectLanguage. Each profile lists the
+// most frequent trigrams of the language, most frequent first, separated by
+// "|". Words are padded with a single space on both sides before splitting
+// into trigrams, so " пр" is a word start and "ть " a word end.
+//
+// The profiles are the top 300 trigrams of the Universal Declaration of
+// Human Rights in each language, as published by franc
+// (https://github.com/wooorm/franc, MIT), without the trigrams spanning two
+// words, which the text is never split into.
+var trigramProfiles = map[string]string{
+	"en": "" +
+		" th|the| an|he |nd |and|ion| of|of |tio| to|to |on | in|al |ati|igh|ght|rig| ri|or |ent|as |ed |is |ll |in | be|ne |one|" +
+		"ver|all|eve| fr| ha| re|ty |ery| or| pr|ht | co| ev|ng |ts |his|ing|be |yon| sh|ce |ree|fre|ryo|her|men|nat|sha|pro|nal|" +
+		"has|es |for| hi|hal|nt | pe| fo|nce|er |ons|res|ect|ity|ly |ry |ers|an | de|cti|dom|edo|eed|hts|ter|ona|re | no| wh| a |" +
+		" un| as|ny |ere| en| na| wi|nit|nte|any|ted| di|ns |sta|th |per|ith|st |om |soc| ar|ch |nti|equ|ve |oci|man| fu|ote|oth|" +
+		"ess| al| ac|wit|ial| ma|uni| se|rea| so| on|lit|int|enc|thi|ual| eq|tat|qua|ive| st|ali|are|con|te |led| is|und|cia|le |" +
+		" la|uma|by | by|hum|ic | hu|ave|ge | wo|ms |com| me|eas|tec| li|en |rat|tit|ple|whe|ate|rot| ch|cie|dis|age|ary|anc|eli|" +
+		"no | fa| su|son|inc|at |nda|hou|wor|nde|rom|oms| ot|eme|tle|iti|gni|itl|duc|whi|act|hic|aw |law| he|ich|min|imi|ort|se |" +
+		"ntr|tra|edu|oun|tan|nst|ld |nta|ble| pu| at|ily|rth|tho|ful|ssi|der|cat|uca|unt|ien| ed|era|ind|pen|sec|omm",
+	"ru": "" +
+		" пр| и |рав|ств| на|пра|го |ени|ове|во | ка|ани|ть | в | по| об|ия |сво| св|лов|на | че|ело| со|ост|чел|ие |ого|ет |ния|" +
+		"ест|аво|ый |ажд| им|ние|век| не|льн|ли |ова|име|ать|при|каж|или|обо| ра|ых |жды| до|дый|воб|ек |бод|ва |его|ся |ии |аци|" +
+		"еет|но |мее|лен|ой |тва|ных|то | ил|енн| бы|ию | за|ми |тво|ван|сто|аль| вс|ом |ьно|их |ног|нов|ако|про|ий |сти|пол|олж|" +
+		"дол|ое |бра| ос|ным|жен|раз|ти |нос| во|тор|все| ег|ей |тел|не |ред|ель|тве|оди| ко|общ| де|има|чес|ним|сно|как| ли|щес|" +
+		"вле|ься|нны|аст|тьс|нно|осу| от|пре|шен|бще|осн|одн|быт|сов|ыть|лжн|ран|нию|иче|ак |ым |ват|что|сту|чен| ст|рес|оль| ни|" +
+		"ном|род|ля |нар|вен|ду |оже|ны | то|вер|зов|нац|ден|рин|туп|ежд|стр| чт|она|дос|тоя|есп|лич|бес|обр|ото|ьны|нии|ую | мо|" +
+		"ем | ме|аро| ре|ава|кот|ав | вы|ам |жно|ста|ая |под|ное| к | та| го|гос|суд|еоб|ен |мож|еск|ели|авн|ве |ече|уще|печ|дно|" +
+		"ход|ка | дл|для|ово|ате|льс|нен|ции|ной|уда|вов| бе|оро|нст|ами|циа|кон|сем|вно| эт|азо|ни |жде|ког|от |дст|вны|сть|ые |" +
+		"пос|сре|тра|ейс|так|дов|му |нал|дру| др|кой|тер|арс|изн|соц|еди|олн",
+	"uk": "" +
+		"на | пр| і |пра|рав| на|ня |ння| за|ого| по|ти |го |люд| лю|во | ко| ма|льн|юди|их | не|аво|анн|дин| св|сво|ожн|кож|енн|" +
+		"пов|жна| до|ати|ина|ає | бу|аці|не |ува|обо| ос| як|має| ви|них|аль|або| та|ні |ть |ови|бо | ві| аб|ере|вин|без|при|іль|" +
+		"ног|ми |та |ом |ою |бод|ста|воб| бе|до |ва |ті | об|ост| в | що|ий |ся | сп|инн|від|ств|ван|нов|нан|кон| у |ват|она|ії |" +
+		"но |дно|ій |езп|пер| де|ути|ьно|ист|під|сті|бут| мо|ідн|ако|нні|ід |тис|що |род|ава| пе|му |соб|ої |спр|ів |ний|яко|ду |" +
+		"вно|ну |аро| ін|ля |рів| рі|нар|нен|ова|ому|лен|нац|ним|ися|чи |ав |ном| ро|нос|ві |вни|овн| її|ові|мож|віл| пі| су|її |" +
+		"одн| вс|ово|ють|іст|сть| ст|буд| ра|чен|про|роз|івн|оду|ьни|ни |сно|зна|рац|им |ими|ції|дер|чин| со|ерж|ди |заб|осо|сі |" +
+		"тер|ніх|кла|спі| ні|ржа|сту|їх |нна|так|зпе| од|абе|для|ту |печ| дл|же |ки |віт|ніс|гал|ага|ами|зах|рим|тан|ког|рес|удь|" +
+		" ре|то |ков|тор|ара|сві|тва|оже|соц|оці|ціа|осн|роб|заг|ахи|хис|піл|цій|лив|осв|іал|руч|інш|ги |аги| ді|ком|ини|оди|нал|" +
+		"тво|кої|всі|ною|об ",
+}
@@ -0,0 +1,163 @@
+package ugucensor
+
+import (
+	"strings"
+	"unicode"
+)
+
+const (
+	// separatorPunct is punctuation left orphaned when the word before or
+	// after it is removed: "Привет, , друг".
+	separatorPunct = ",;:-–—"
+	// terminalPunct ends a sentence; it stays when the last word of the
+	// sentence is removed, and goes along with a sentence removed whole.
+	terminalPunct = ".!?…"
+	// closingPunct is punctuation written without a space before it.
+	closingPunct = ",;:.!?…)]}»”"
+)
+
+// bracketPairs maps opening brackets and quotes to their closing ones,
+// which are dropped together when only a removed word was between them.
+var bracketPairs = map[rune]rune{
+	'(':  ')',
+	'[':  ']',
+	'{':  '}',
+	'«':  '»',
+	'“':  '”',
+	'„':  '“',
+	'"':  '"',
+	'\'': '\'',
+}
+
+// RemoveBadWords deletes the bad words from the text instead of masking
+// them and cleans up around them: the spaces left are collapsed, the
+// punctuation orphaned by the removal (", ," or "( )", or the "?" of a
+// sentence removed whole) is dropped, as are the separators left at the
+// start or the end of the text. "Привет, игра, друг!" becomes "Привет,
+// друг!", "Это (игра)." becomes "Это.", "Игра? Да." becomes "Да.".
+//
+// It reports whether the text had bad words.
+func (c *Censor) RemoveBadWords(text string, lang string) (string, bool) {
+	runes := []rune(text)
+
+	matches := c.findMatches(runes, lang)
+	if len(matches) == 0 {
+		return c.maskMatches(runes, nil), false
+	}
+
+	var (
+		out []rune
+		// sep is the whitespace removed at the end of out that is still
+		// to be written before the next text.
+		sep  string
+		prev int
+	)
+	pieces := make([][]rune, 0, len(matches)+1)
+	for _, m := range matches {
+		pieces = append(pieces, c.visibleRunes(runes[prev:m.Start]))
+		prev = m.End
+	}
+	pieces = append(pieces, c.visibleRunes(runes[prev:]))
+
+	out = pieces[0]
+	for i, piece := range pieces[1:] {
+		last := i == len(pieces)-2
+		out, piece, sep = joinRemoved(out, piece, sep, last)
+		if len(piece) == 0 && !last {
+			continue
+		}
+		if len(out) > 0 && len(piece) > 0 && !strings.ContainsRune(closingPunct, piece[0]) && !isOpening(out[len(out)-1]) {
+			out = append(out, []rune(sep)...)
+		}
+		out = append(out, piece...)
+		sep = ""
+	}
+	return string(out), true
+}
+
+// joinRemoved cleans up the text left and right of a removed word and
+// returns them with the whitespace to put between them: "\n" if a line
+// break was removed, " " if any other whitespace was, "" if none.
+func joinRemoved(left []rune, right []rune, sep string, last bool) ([]rune, []rune, string) {
+	for {
+		var removed string
+		left, removed = trimSpaceRight(left)
+		sep = mergeSep(sep, removed)
+		right, removed = trimSpaceLeft(right)
+		sep = mergeSep(sep, removed)
+
+		changed := false
+		switch {
+		case len(left) > 0 && len(right) > 0 && sep == "" && bracketPairs[left[len(left)-1]] == right[0]:
+			// nothing is left between the brackets
+			left, right = left[:len(left)-1], right[1:]
+			changed = true
+
+		case len(right) > 0 && strings.ContainsRune(separatorPunct, right[0]) &&
+			(len(left) == 0 || isPunct(left[len(left)-1])):
+			right = right[1:]
+			changed = true
+
+		case len(right) > 0 && strings.ContainsRune(terminalPunct, right[0]) &&
+			(len(left) == 0 || strings.ContainsRune(terminalPunct, left[len(left)-1])):
+			// the removed word was the whole sentence
+			right = right[1:]
+			changed = true
+
+		case len(left) > 0 && strings.ContainsRune(separatorPunct, left[len(left)-1]) &&
+			(len(right) > 0 && strings.ContainsRune(terminalPunct, right[0]) || len(right) == 0 && last):
+			left = left[:len(left)-1]
+			changed = true
+		}
+		if !changed {
+			return left, right, sep
+		}
+	}
+}
+
+// mergeSep merges the whitespace removed into the separator to write.
+func mergeSep(sep string, removed string) string {
+	switch {
+	case sep == "\n" || strings.ContainsRune(removed, '\n'):
+		return "\n"
+	case sep == " " || removed != "":
+		return " "
+	}
+	return ""
+}
+
+func trimSpaceRight(runes []rune) ([]rune, string) {
+	i := len(runes)
+	for i > 0 && unicode.IsSpace(runes[i-1]) {
+		i--
+	}
+	return runes[:i], string(runes[i:])
+}
+
+func trimSpaceLeft(runes []rune) ([]rune, string) {
+	i := 0
+	for i < len(runes) && unicode.IsSpace(runes[i]) {
+		i++
+	}
+	return runes[i:], string(runes[:i])
+}
+
+func isPunct(ch rune) bool {
+	return unicode.IsPunct(ch) && !strings.ContainsRune(`)]}»”"'`, ch)
+}
+
+func isOpening(ch rune) bool {
+	return strings.ContainsRune("([{«“„", ch)
+}
+
+// visibleRunes returns a copy of runes without invisible characters if the
+// Censor was created with WithStripInvisible.
+func (c *Censor) visibleRunes(runes []rune) []rune {
+	visible := make([]rune, 0, len(runes))
+	for _, ch := range runes {
+		if !c.stripInvisible || !isInvisible(ch) {
+			visible = append(visible, ch)
+		}
+	}
+	return visible
+}
@@ -0,0 +1,76 @@
+package ugucensor
+
+import (
+	"testing"
+)
+
+func TestCensor_RemoveBadWords(t *testing.T) {
+	c := NewCensor()
+	c.AddWords([]string{"игра", "яблоко"}, "ru")
+	c.AddWords([]string{"game"}, "en")
+
+	f := func(text string, lang string, expected string, expectedCensored bool) {
+		t.Helper()
+
+		got, gotCensored := c.RemoveBadWords(text, lang)
+		if got != expected || gotCensored != expectedCensored {
+			t.Errorf("RemoveBadWords(%q) = %q, %v; want %q, %v", text, got, gotCensored, expected, expectedCensored)
+		}
+	}
+
+	t.Run("spaces", func(t *testing.T) {
+		f("это игра и мир", "ru", "это и мир", true)
+		f("это  игра  игры   мир", "ru", "это мир", true)
+		f("мир\nигра\nдруг", "ru", "мир\nдруг", true)
+		f("мир как мир", "ru", "мир как мир", false)
+	})
+
+	t.Run("leading and trailing separators", func(t *testing.T) {
+		f("  игра, привет", "ru", "привет", true)
+		f("игра — это мир", "ru", "это мир", true)
+		f("привет, игра", "ru", "привет", true)
+		f("это — игра ", "ru", "это", true)
+		f("игра", "ru", "", true)
+		f("игра, яблоко!", "ru", "", true)
+	})
+
+	t.Run("orphaned sentence ends", func(t *testing.T) {
+		f("Игра? Да.", "ru", "Да.", true)
+		f("Привет! Игра. Мир", "ru", "Привет! Мир", true)
+		f("Мир. Игра... Да", "ru", "Мир. Да", true)
+		f("Мир, игра.", "ru", "Мир.", true)
+	})
+
+	t.Run("orphaned punctuation", func(t *testing.T) {
+		f("Привет, игра, друг!", "ru", "Привет, друг!", true)
+		f("Привет, игра!", "ru", "Привет!", true)
+		f("Это игра.", "ru", "Это.", true)
+		f("мир, игра, яблоко, друг", "ru", "мир, друг", true)
+		f("Это (игра).", "ru", "Это.", true)
+		f("Это «игра» и (мир)", "ru", "Это и (мир)", true)
+		f("a \"game\" b", "en", "a b", true)
+		f("(игра, мир)", "ru", "(мир)", true)
+		f("мир; игра: друг", "ru", "мир; друг", true)
+	})
+
+	t.Run("words inside words", func(t *testing.T) {
+		c := NewCensor(WithInfixMatching(4))
+		c.AddWord("игра", "ru")
+
+		if got, _ := c.RemoveBadWords("суперигра!", "ru"); got != "супер!" {
+			t.Errorf("RemoveBadWords(%q) = %q; want %q", "суперигра!", got, "супер!")
+		}
+	})
+
+	t.Run("invisible characters", func(t *testing.T) {
+		c := NewCensor(WithStripInvisible())
+		c.AddWord("игра", "ru")
+
+		if got, _ := c.RemoveBadWords("ми\u200bр и\u200bгра", "ru"); got != "мир" {
+			t.Errorf("RemoveBadWords() = %q; want %q", got, "мир")
+		}
+		if got, _ := c.RemoveBadWords("ми\u200bр", "ru"); got != "мир" {
+			t.Errorf("RemoveBadWords() = %q; want %q", got, "мир")
+		}
+	})
+}
@@ -0,0 +1,31 @@
+package ugucensor
+
+// findReversedMatches finds words spelled backwards, like "арги" for
+// "игра": the text is reversed and searched with the same start and bound
+// logic as the text itself, and the spans are mapped back.
+func (c *Censor) findReversedMatches(runes []rune, lang string) []Match {
+	n := len(runes)
+	reversed := make([]rune, n)
+	for i, ch := range runes {
+		reversed[n-1-i] = ch
+	}
+
+	starts, _ := c.findPossibleBadWordStarts(reversed, lang)
+
+	var matches []Match
+	for _, wb := range c.findPossibleBadWordBounds(reversed, starts, lang) {
+		if e, ok := c.lookup(wb.Word, wb.BadPart, lang); ok {
+			matches = append(matches, Match{
+				Start:      n - wb.End,
+				End:        n - wb.Start,
+				Word:       wb.Word,
+				Entry:      e.word,
+				Severity:   e.severity,
+				Category:   e.category,
+				Kind:       MatchReversed,
+				Confidence: 1,
+			})
+		}
+	}
+	return matches
+}
@@ -0,0 +1,74 @@
+package ugucensor
+
+import "testing"
+
+func TestCensor_CensorText_Reversed(t *testing.T) {
+	c := NewCensor(WithReversedMatching())
+	c.AddWords([]string{"яблоко", "игра"}, "ru")
+
+	f := func(text string, expected string, expectedCensored bool) {
+		t.Helper()
+
+		got, gotCensored := c.CensorText(text, "ru")
+		if got != expected || gotCensored != expectedCensored {
+			t.Errorf("CensorText(%q) = %q, %v; want %q, %v", text, got, gotCensored, expected, expectedCensored)
+		}
+	}
+
+	t.Run("reversed words", func(t *testing.T) {
+		f("арги", "****", true)
+		f("вот околбя!", "вот ******!", true)
+		f("АРГИ тут", "**** тут", true)
+	})
+
+	t.Run("reversed inflected words", func(t *testing.T) {
+		f("иколбя", "******", true)
+		f("ыргиг", "ыргиг", false)
+	})
+
+	t.Run("plain words still match", func(t *testing.T) {
+		f("игра", "****", true)
+		f("игра и арги", "**** и ****", true)
+	})
+
+	t.Run("clean text", func(t *testing.T) {
+		f("грипп", "грипп", false)
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		c = NewCensor()
+		c.AddWord("игра", "ru")
+		f("арги", "арги", false)
+	})
+}
+
+func TestCensor_FindMatches_Reversed(t *testing.T) {
+	c := NewCensor(WithReversedMatching())
+	c.AddWord("игра", "ru")
+
+	got := c.FindMatches("и ыргИ", "ru")
+	if len(got) != 1 {
+		t.Fatalf("FindMatches() = %+v; want one match", got)
+	}
+	m := got[0]
+	if m.Start != 2 || m.End != 6 || m.Word != "игры" || m.Kind != MatchReversed || !m.Kind.Obfuscated() {
+		t.Errorf("FindMatches() = %+v; want a reversed match of \"игры\" at 2:6", m)
+	}
+}
+
+func TestMatchKind_Obfuscated(t *testing.T) {
+	f := func(kind MatchKind, expected bool) {
+		t.Helper()
+
+		if got := kind.Obfuscated(); got != expected {
+			t.Errorf("%v.Obfuscated() = %v; want %v", kind, got, expected)
+		}
+	}
+
+	f(MatchExact, false)
+	f(MatchPrefixed, false)
+	f(MatchFuzzy, false)
+	f(MatchTranslit, true)
+	f(MatchSelfCensored, true)
+	f(MatchReversed, true)
+}
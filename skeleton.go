@@ -0,0 +1,125 @@
+package ugucensor
+
+import (
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/machine23/ugu-censor/trie"
+)
+
+// skeletonConfidence is the confidence of skeleton matches: many words
+// share a skeleton, so a vowel-less word only probably means the entry.
+const skeletonConfidence = 0.5
+
+// skeletonLetters holds, for every language, the vowels dropped from words
+// to get their consonant skeleton, and the silent letters dropped along
+// with them.
+var skeletonLetters = map[string]struct {
+	vowels string
+	silent string
+}{
+	"en": {vowels: "aeiouy"},
+	"ru": {vowels: "аеёиоуыэюя", silent: "ьъ"},
+	"uk": {vowels: "аеєиіїоуюя", silent: "ь"},
+	"be": {vowels: "аеёіоуыэюя", silent: "ь"},
+}
+
+// skeleton returns the consonants of the word, or false if the language
+// has no vowel table.
+func skeleton(word []rune, lang string) ([]rune, bool) {
+	letters, ok := skeletonLetters[lang]
+	if !ok {
+		return nil, false
+	}
+	var consonants []rune
+	for _, ch := range word {
+		if unicode.IsLetter(ch) &&
+			!strings.ContainsRune(letters.vowels, ch) &&
+			!strings.ContainsRune(letters.silent, ch) {
+			consonants = append(consonants, ch)
+		}
+	}
+	return consonants, true
+}
+
+// addSkeleton indexes the entry by the skeleton of the dictionary key if
+// the skeleton is long enough for skeleton matching.
+func (c *Censor) addSkeleton(key string, lang string, e *entry) {
+	consonants, ok := skeleton([]rune(key), lang)
+	if !ok || len(consonants) < c.skeletonMinLen {
+		return
+	}
+	if _, ok := c.skeletons[lang]; !ok {
+		c.skeletons[lang] = trie.NewTrie()
+		c.skeletonEntries[lang] = make(map[string]*entry)
+	}
+	c.skeletons[lang].Insert(string(consonants))
+	c.skeletonEntries[lang][string(consonants)] = e
+}
+
+// findSkeletonMatches finds words written without their vowels, like
+// "грк" or "бл*к", whose consonants are the skeleton of a dictionary word.
+func (c *Censor) findSkeletonMatches(runes []rune, lang string, found []Match) []Match {
+	index, ok := c.skeletons[lang]
+	if !ok {
+		return nil
+	}
+	vowels := skeletonLetters[lang].vowels
+
+	var matches []Match
+	for _, tok := range uncoveredTokens(c.skeletonTokens(runes), found) {
+		word := runes[tok.start:tok.end]
+		if strings.ContainsFunc(string(word), func(ch rune) bool {
+			return strings.ContainsRune(vowels, ch)
+		}) {
+			continue
+		}
+
+		consonants, _ := skeleton(word, lang)
+		if len(consonants) < c.skeletonMinLen || !index.Search(string(consonants)) {
+			continue
+		}
+		e := c.skeletonEntries[lang][string(consonants)]
+		matches = append(matches, Match{
+			Start:      tok.start,
+			End:        tok.end,
+			Word:       string(consonants),
+			Entry:      e.word,
+			Severity:   e.severity,
+			Category:   e.category,
+			Kind:       MatchSkeleton,
+			Confidence: skeletonConfidence,
+		})
+	}
+	return matches
+}
+
+// skeletonTokens returns the runs of letters and the wildcard symbols
+// standing for vowels between them. Any other character ends a run, so
+// separate letters like "г, р, к" are not read as a word.
+func (c *Censor) skeletonTokens(runes []rune) []token {
+	var tokens []token
+	for i := 0; i < len(runes); i++ {
+		if !unicode.IsLetter(runes[i]) {
+			continue
+		}
+		start, end := i, i
+		for ; i < len(runes) && (unicode.IsLetter(runes[i]) || c.isSkeletonWildcard(runes[i])); i++ {
+			if unicode.IsLetter(runes[i]) {
+				end = i + 1
+			}
+		}
+		tokens = append(tokens, token{start, end})
+	}
+	return tokens
+}
+
+// isSkeletonWildcard reports whether the character stands for a vowel:
+// one of the symbols of WithWildcards, or of the default ones without it.
+func (c *Censor) isSkeletonWildcard(ch rune) bool {
+	if len(c.wildcards) == 0 {
+		return slices.Contains(defaultWildcards, ch)
+	}
+	return c.isWildcard(ch)
+}
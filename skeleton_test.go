@@ -0,0 +1,76 @@
+package ugucensor
+
+import "testing"
+
+func TestCensor_CensorText_Skeleton(t *testing.T) {
+	c := NewCensor(WithSkeletonMatching(3))
+	c.AddWords([]string{"яблоко", "игрок", "игра"}, "ru")
+
+	f := func(text string, expected string, expectedCensored bool) {
+		t.Helper()
+
+		got, gotCensored := c.CensorText(text, "ru")
+		if got != expected || gotCensored != expectedCensored {
+			t.Errorf("CensorText(%q) = %q, %v; want %q, %v", text, got, gotCensored, expected, expectedCensored)
+		}
+	}
+
+	t.Run("dropped vowels", func(t *testing.T) {
+		f("это блк", "это ***", true)
+		f("ГРК тут", "*** тут", true)
+	})
+
+	t.Run("vowels replaced with symbols", func(t *testing.T) {
+		f("бл*к", "****", true)
+		f("гр#к!", "****!", true)
+	})
+
+	t.Run("separate letters", func(t *testing.T) {
+		f("г, р, к", "г, р, к", false)
+		f("г.р.к", "г.р.к", false)
+		f("бл.к", "бл.к", false)
+	})
+
+	t.Run("short skeleton", func(t *testing.T) {
+		// "игра" has the skeleton "гр", shorter than minLen
+		f("гр", "гр", false)
+	})
+
+	t.Run("words with vowels", func(t *testing.T) {
+		f("блок", "блок", false)
+		f("грек", "грек", false)
+	})
+
+	t.Run("exact match still wins", func(t *testing.T) {
+		f("яблоко", "******", true)
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		c = NewCensor()
+		c.AddWord("яблоко", "ru")
+		f("блк", "блк", false)
+	})
+}
+
+func TestCensor_FindMatches_Skeleton(t *testing.T) {
+	c := NewCensor(WithSkeletonMatching(3))
+	c.AddWord("яблоко", "ru", EntrySeverity(SeverityHigh))
+
+	got := c.FindMatches("и блк", "ru")
+	expected := Match{
+		Start:      2,
+		End:        5,
+		ByteStart:  3,
+		ByteEnd:    9,
+		UTF16Start: 2,
+		UTF16End:   5,
+		Word:       "блк",
+		Entry:      "яблоко",
+		Severity:   SeverityHigh,
+		Kind:       MatchSkeleton,
+		Confidence: skeletonConfidence,
+	}
+	if len(got) != 1 || got[0] != expected {
+		t.Errorf("FindMatches() = %+v; want %+v", got, expected)
+	}
+}
@@ -0,0 +1,132 @@
+package ugucensor
+
+import (
+	"strings"
+	"unicode"
+)
+
+// minSpacedLetters is the minimum number of single letters in a row to be
+// read as one spaced-out word, so that standalone one-letter words like
+// "я" or "и" are left alone.
+const minSpacedLetters = 3
+
+// oneLetterWords holds, for every language, the words of a single letter,
+// which may stand before a spaced-out word without being part of it.
+var oneLetterWords = map[string]string{
+	"ru": "авиосуяк",
+	"uk": "авзіоуяйе",
+	"be": "аві",
+	"en": "ai",
+}
+
+// findSpacedMatches finds words spelled letter by letter with spaces or
+// punctuation in between ("и г р а", "я.б.л.о.к.о"): runs of single-letter
+// words are reassembled and checked with the stem path. The match spans
+// from the first to the last letter of the word.
+func (c *Censor) findSpacedMatches(runes []rune, lang string) []Match {
+	var matches []Match
+	for _, run := range spacedRuns(runes) {
+		// like plain words, a spaced word starts at the start of the run,
+		// right after another one or after one-letter words like "в" or
+		// "а", never inside a longer word
+		for first := 0; first+minSpacedLetters <= len(run); first++ {
+			if found := c.matchSpacedRun(runes, run[first:], lang); len(found) > 0 {
+				matches = append(matches, found...)
+				break
+			}
+			if !isOneLetterWord(runes[run[first]], lang) {
+				break
+			}
+		}
+	}
+	return matches
+}
+
+// matchSpacedRun returns the words spelled one after another from the
+// start of the run.
+func (c *Censor) matchSpacedRun(runes []rune, run []int, lang string) []Match {
+	var matches []Match
+	for s := 0; s+minSpacedLetters <= len(run); {
+		m, n := c.matchSpaced(runes, run[s:], lang)
+		if n == 0 {
+			break
+		}
+		matches = append(matches, m)
+		s += n
+	}
+	return matches
+}
+
+func isOneLetterWord(ch rune, lang string) bool {
+	return strings.ContainsRune(oneLetterWords[lang], unicode.ToLower(ch))
+}
+
+// matchSpaced returns the longest word at the start of the run of letter
+// positions found in the dictionary and the number of its letters, zero if
+// there is none.
+func (c *Censor) matchSpaced(runes []rune, run []int, lang string) (Match, int) {
+	word := make([]rune, len(run))
+	for i, pos := range run {
+		word[i] = runes[pos]
+	}
+
+	for n := len(run); n >= minSpacedLetters; n-- {
+		if m, ok := c.matchWord(word[:n], lang); ok {
+			m.Start, m.End = run[0], run[n-1]+1
+			m.Kind = MatchSpaced
+			return m, n
+		}
+	}
+	return Match{}, 0
+}
+
+// spacedRuns returns the positions of letters standing alone between
+// non-letters, grouped into runs of at least minSpacedLetters letters.
+// All letters of a run are separated the same way, so "и г р а, а я" yields
+// the run "игра" only; a line break always ends a run.
+func spacedRuns(runes []rune) [][]int {
+	var (
+		runs [][]int
+		run  []int
+		sep  string
+	)
+	flush := func() {
+		if len(run) >= minSpacedLetters {
+			runs = append(runs, run)
+		}
+		run = nil
+	}
+
+	for i := 0; i < len(runes); i++ {
+		if !unicode.IsLetter(runes[i]) {
+			continue
+		}
+		if i+1 < len(runes) && unicode.IsLetter(runes[i+1]) {
+			// a word of several letters ends the run
+			flush()
+			for i+1 < len(runes) && unicode.IsLetter(runes[i+1]) {
+				i++
+			}
+			continue
+		}
+
+		if len(run) > 0 {
+			prev := run[len(run)-1]
+			gap := string(runes[prev+1 : i])
+			switch {
+			case strings.ContainsRune(gap, '\n'):
+				flush()
+			case len(run) == 1:
+				sep = gap
+			case gap != sep:
+				// the previous letter may start a run separated by gap
+				flush()
+				run, sep = []int{prev}, gap
+			}
+		}
+		run = append(run, i)
+	}
+	flush()
+
+	return runs
+}
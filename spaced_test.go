@@ -0,0 +1,66 @@
+package ugucensor
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCensor_CensorText_Spaced(t *testing.T) {
+	c := NewCensor()
+	c.AddWords([]string{"игра", "игрок", "яблоко"}, "ru")
+
+	f := func(text string, expected string, expectedCensored bool) {
+		t.Helper()
+
+		got, gotCensored := c.CensorText(text, "ru")
+		if got != expected || gotCensored != expectedCensored {
+			t.Errorf("\nCensorText(%q, \"ru\")\n\tgot : %s, %v\n\twant: %s, %v", text, got, gotCensored, expected, expectedCensored)
+		}
+	}
+
+	t.Run("spaced letters", func(t *testing.T) {
+		f("и г р а", "*******", true)
+		f("я б л о к о", "***********", true)
+		f("это я б л о к и!", "это ***********!", true)
+		f("я - б - л - о - к - о", "*********************", true)
+		f("и_г_р_о_к", "*********", true)
+	})
+
+	t.Run("several words in a run", func(t *testing.T) {
+		f("и г р а и г р а", "******* *******", true)
+		f("а и г р а", "а *******", true)
+		f("в и г р а", "в *******", true)
+		f("это в я б л о к о", "это в ***********", true)
+		f("а в и г р а", "а в *******", true)
+		f("я, и г р а", "я, *******", true)
+	})
+
+	t.Run("no words inside words", func(t *testing.T) {
+		g := func(text string, expected ...string) {
+			t.Helper()
+
+			runes := []rune(text)
+			matches := c.findSpacedMatches(c.normalize(runes, "ru").runes, "ru")
+			var got []string
+			for _, m := range matches {
+				got = append(got, string(runes[m.Start:m.End]))
+			}
+			if len(got) != len(expected) || strings.Join(got, "|") != strings.Join(expected, "|") {
+				t.Errorf("findSpacedMatches(%q) = %q; want %q", text, got, expected)
+			}
+		}
+
+		g("т и г р а")
+		g("т и г р о к")
+		g("в т и г р а")
+		g("и г р а и г р а", "и г р а", "и г р а")
+		g("и г р а т и г р о к", "и г р а")
+	})
+
+	t.Run("single-letter words", func(t *testing.T) {
+		f("я", "я", false)
+		f("я и ты", "я и ты", false)
+		f("а я и", "а я и", false)
+		f("и г р", "*****", true)
+	})
+}
@@ -0,0 +1,225 @@
+package ugucensor
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// structTag is a parsed `censor:"..."` struct tag.
+type structTag struct {
+	// lang is the language of the field, or "auto" to detect it.
+	lang string
+	mask MaskStyle
+}
+
+// maskStyles maps the names used in struct tags to mask styles.
+var maskStyles = map[string]MaskStyle{
+	"full":      MaskFull,
+	"keepfirst": MaskKeepFirst,
+	"keepedges": MaskKeepEdges,
+}
+
+// CensorStruct censors the string fields of the struct v points to that
+// are tagged with the language of their text:
+//
+//	type Comment struct {
+//		Title string   `censor:"ru"`
+//		Body  string   `censor:"auto,mask=keepfirst"`
+//		Tags  []string `censor:"en"`
+//	}
+//
+// The language "auto" detects it as CensorAuto does. The mask option sets
+// the MaskStyle: full (the default), keepfirst or keepedges. A tag on a
+// slice, array, map, pointer or interface field applies to the strings in
+// it. Nested structs are walked whether tagged or not, through pointers,
+// slices, arrays and maps as well; unexported fields are skipped, except
+// embedded structs, whose exported fields are promoted.
+//
+// It reports whether any field changed, and returns an error if v is not
+// a non-nil pointer to a struct or a tag is malformed.
+func (c *Censor) CensorStruct(v any) (bool, error) {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return false, fmt.Errorf("ugucensor: CensorStruct needs a non-nil pointer to a struct, got %T", v)
+	}
+
+	sw := structWalker{c: c, visited: make(map[visit]bool)}
+	return sw.value(rv, nil)
+}
+
+// structWalker walks a value censoring the strings under tagged fields.
+type structWalker struct {
+	c *Censor
+	// visited holds the pointers, maps and slices walked already, so
+	// cycles end.
+	visited map[visit]bool
+}
+
+// visit is a pointer, map or slice walked by structWalker. A pointer to
+// a struct and to its first field share the address, so the type tells
+// them apart, and the length tells apart slices of the same array.
+type visit struct {
+	ptr uintptr
+	typ reflect.Type
+	len int
+}
+
+// enter marks v walked and reports whether it was not walked before.
+func (sw *structWalker) enter(v reflect.Value) bool {
+	key := visit{ptr: v.Pointer(), typ: v.Type()}
+	if v.Kind() == reflect.Slice {
+		key.len = v.Len()
+	}
+	if sw.visited[key] {
+		return false
+	}
+	sw.visited[key] = true
+	return true
+}
+
+// value walks v; tag is the tag of the field v belongs to, nil if the
+// field is not tagged.
+func (sw *structWalker) value(v reflect.Value, tag *structTag) (bool, error) {
+	switch v.Kind() {
+	case reflect.String:
+		if tag == nil || !v.CanSet() {
+			return false, nil
+		}
+		censored, changed := sw.c.censorString(v.String(), tag)
+		if changed {
+			v.SetString(censored)
+		}
+		return changed, nil
+
+	case reflect.Struct:
+		return sw.structFields(v)
+
+	case reflect.Pointer:
+		if v.IsNil() || !sw.enter(v) {
+			return false, nil
+		}
+		return sw.value(v.Elem(), tag)
+
+	case reflect.Interface:
+		if v.IsNil() {
+			return false, nil
+		}
+		// the value in an interface cannot be set, so a copy is walked
+		// and put back if it changed
+		elem := reflect.New(v.Elem().Type()).Elem()
+		elem.Set(v.Elem())
+		changed, err := sw.value(elem, tag)
+		if changed && v.CanSet() {
+			v.Set(elem)
+		}
+		return changed, err
+
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice && (v.Len() == 0 || !sw.enter(v)) {
+			return false, nil
+		}
+		changed := false
+		for i := 0; i < v.Len(); i++ {
+			elemChanged, err := sw.value(v.Index(i), tag)
+			if err != nil {
+				return changed, err
+			}
+			changed = changed || elemChanged
+		}
+		return changed, nil
+
+	case reflect.Map:
+		if v.Len() == 0 || !sw.enter(v) {
+			return false, nil
+		}
+		changed := false
+		iter := v.MapRange()
+		for iter.Next() {
+			elem := reflect.New(iter.Value().Type()).Elem()
+			elem.Set(iter.Value())
+			elemChanged, err := sw.value(elem, tag)
+			if err != nil {
+				return changed, err
+			}
+			if elemChanged {
+				v.SetMapIndex(iter.Key(), elem)
+				changed = true
+			}
+		}
+		return changed, nil
+	}
+	return false, nil
+}
+
+func (sw *structWalker) structFields(v reflect.Value) (bool, error) {
+	changed := false
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		// an embedded struct of an unexported type still has exported
+		// fields, promoted to the outer struct
+		embeddedStruct := field.Anonymous && (field.Type.Kind() == reflect.Struct ||
+			field.Type.Kind() == reflect.Pointer && field.Type.Elem().Kind() == reflect.Struct)
+		if !field.IsExported() && !embeddedStruct {
+			continue
+		}
+
+		var tag *structTag
+		if s, ok := field.Tag.Lookup("censor"); ok {
+			if s == "-" {
+				continue
+			}
+			parsed, err := parseStructTag(s)
+			if err != nil {
+				return changed, fmt.Errorf("ugucensor: field %s.%s: %w", t.Name(), field.Name, err)
+			}
+			tag = &parsed
+		}
+
+		fieldChanged, err := sw.value(v.Field(i), tag)
+		if err != nil {
+			return changed, err
+		}
+		changed = changed || fieldChanged
+	}
+	return changed, nil
+}
+
+// parseStructTag parses a tag like "ru" or "auto,mask=keepfirst".
+func parseStructTag(s string) (structTag, error) {
+	parts := strings.Split(s, ",")
+	tag := structTag{lang: strings.TrimSpace(parts[0])}
+	if tag.lang == "" {
+		return tag, fmt.Errorf("no language in censor tag %q", s)
+	}
+
+	for _, opt := range parts[1:] {
+		name, value, _ := strings.Cut(strings.TrimSpace(opt), "=")
+		switch name {
+		case "mask":
+			style, ok := maskStyles[value]
+			if !ok {
+				return tag, fmt.Errorf("unknown mask %q in censor tag %q", value, s)
+			}
+			tag.mask = style
+		default:
+			return tag, fmt.Errorf("unknown option %q in censor tag %q", name, s)
+		}
+	}
+	return tag, nil
+}
+
+// censorString censors a string field with the language and the mask
+// style of its tag.
+func (c *Censor) censorString(s string, tag *structTag) (string, bool) {
+	runes := []rune(s)
+
+	matches := c.findLangMatches(runes, tag.lang)
+	if len(matches) == 0 && !c.stripInvisible {
+		return s, false
+	}
+
+	censored := c.maskMatchesStyle(runes, matches, tag.mask)
+	return censored, censored != s
+}
@@ -0,0 +1,180 @@
+package ugucensor
+
+import (
+	"reflect"
+	"testing"
+)
+
+type testAuthor struct {
+	Name string  `censor:"ru"`
+	Bio  *string `censor:"ru,mask=keepedges"`
+}
+
+type testComment struct {
+	ID     string
+	Title  string            `censor:"ru"`
+	Body   string            `censor:"auto,mask=keepfirst"`
+	Tags   []string          `censor:"ru"`
+	Meta   map[string]string `censor:"ru"`
+	Extra  any               `censor:"ru"`
+	Skip   string            `censor:"-"`
+	Author testAuthor
+	Reply  *testComment
+	Votes  []testAuthor
+	ByLang map[string]testAuthor
+
+	note string `censor:"ru"`
+}
+
+func TestCensor_CensorStruct(t *testing.T) {
+	c := NewCensor()
+	c.AddWords([]string{"игра", "яблоко"}, "ru")
+
+	bio := "моя игра"
+	comment := &testComment{
+		ID:     "игра",
+		Title:  "игра",
+		Body:   "это игра и яблоко",
+		Tags:   []string{"мир", "игра"},
+		Meta:   map[string]string{"игра": "игра", "b": "мир"},
+		Extra:  "игра",
+		Skip:   "игра",
+		Author: testAuthor{Name: "игра", Bio: &bio},
+		Reply:  &testComment{Title: "яблоко"},
+		Votes:  []testAuthor{{Name: "игра"}},
+		ByLang: map[string]testAuthor{"ru": {Name: "игра"}},
+		note:   "игра",
+	}
+	comment.Reply.Reply = comment
+
+	changed, err := c.CensorStruct(comment)
+	if err != nil || !changed {
+		t.Fatalf("CensorStruct() = %v, %v; want true, nil", changed, err)
+	}
+
+	f := func(name string, got any, expected any) {
+		t.Helper()
+
+		if !reflect.DeepEqual(got, expected) {
+			t.Errorf("%s = %#v; want %#v", name, got, expected)
+		}
+	}
+
+	f("ID", comment.ID, "игра")
+	f("Title", comment.Title, "****")
+	f("Body", comment.Body, "это и*** и я*****")
+	f("Tags", comment.Tags, []string{"мир", "****"})
+	f("Meta", comment.Meta, map[string]string{"игра": "****", "b": "мир"})
+	f("Extra", comment.Extra, "****")
+	f("Skip", comment.Skip, "игра")
+	f("Author.Name", comment.Author.Name, "****")
+	f("Author.Bio", *comment.Author.Bio, "моя и**а")
+	f("Reply.Title", comment.Reply.Title, "******")
+	f("Votes", comment.Votes[0].Name, "****")
+	f("ByLang", comment.ByLang["ru"].Name, "****")
+	f("note", comment.note, "игра")
+}
+
+type testBase struct {
+	Title string `censor:"ru"`
+	note  string `censor:"ru"`
+}
+
+type testPost struct {
+	testBase
+	*testAuthor
+	Body string `censor:"ru"`
+}
+
+func TestCensor_CensorStruct_Embedded(t *testing.T) {
+	c := NewCensor()
+	c.AddWord("игра", "ru")
+
+	post := &testPost{
+		testBase:   testBase{Title: "игра", note: "игра"},
+		testAuthor: &testAuthor{Name: "игра"},
+		Body:       "игра",
+	}
+	changed, err := c.CensorStruct(post)
+	if err != nil || !changed {
+		t.Fatalf("CensorStruct() = %v, %v; want true, nil", changed, err)
+	}
+	if post.Title != "****" || post.Name != "****" || post.Body != "****" || post.note != "игра" {
+		t.Errorf("CensorStruct() = %+v, %+v; want the promoted fields censored", post.testBase, *post.testAuthor)
+	}
+}
+
+func TestCensor_CensorStruct_Cycles(t *testing.T) {
+	c := NewCensor()
+	c.AddWord("игра", "ru")
+
+	m := map[string]any{"title": "игра"}
+	m["self"] = m
+	s := []any{"игра", nil}
+	s[1] = s
+
+	comment := &testComment{Extra: []any{m, s}}
+	changed, err := c.CensorStruct(comment)
+	if err != nil || !changed {
+		t.Fatalf("CensorStruct() = %v, %v; want true, nil", changed, err)
+	}
+	if m["title"] != "****" || s[0] != "****" {
+		t.Errorf("CensorStruct() = %v, %v; want the strings censored", m["title"], s[0])
+	}
+}
+
+func TestCensor_CensorStruct_Unchanged(t *testing.T) {
+	c := NewCensor()
+	c.AddWord("игра", "ru")
+
+	comment := &testComment{Title: "мир"}
+	if changed, err := c.CensorStruct(comment); changed || err != nil {
+		t.Errorf("CensorStruct() = %v, %v; want false, nil", changed, err)
+	}
+}
+
+func TestCensor_CensorStruct_Errors(t *testing.T) {
+	c := NewCensor()
+	c.AddWord("игра", "ru")
+
+	f := func(v any) {
+		t.Helper()
+
+		if _, err := c.CensorStruct(v); err == nil {
+			t.Errorf("CensorStruct(%#v) = nil error; want an error", v)
+		}
+	}
+
+	f(nil)
+	f(testComment{})
+	f((*testComment)(nil))
+	f(&[]string{"игра"})
+	f(&struct {
+		A string `censor:"ru,mask=blur"`
+	}{})
+	f(&struct {
+		A string `censor:",mask=full"`
+	}{})
+	f(&struct {
+		A string `censor:"ru,color=red"`
+	}{})
+}
+
+func TestCensor_maskMatchesStyle(t *testing.T) {
+	c := NewCensor()
+	c.AddWord("игра", "ru")
+
+	f := func(text string, style MaskStyle, expected string) {
+		t.Helper()
+
+		runes := []rune(text)
+		if got := c.maskMatchesStyle(runes, c.findMatches(runes, "ru"), style); got != expected {
+			t.Errorf("maskMatchesStyle(%q, %v) = %q; want %q", text, style, got, expected)
+		}
+	}
+
+	f("игра!", MaskFull, "****!")
+	f("игра!", MaskKeepFirst, "и***!")
+	f("игра!", MaskKeepEdges, "и**а!")
+	f("и\u200bгра", MaskKeepEdges, "и**а")
+}
@@ -0,0 +1,249 @@
+package ugucensor
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rivo/uniseg"
+)
+
+// Cue is a subtitle cue that had bad words.
+type Cue struct {
+	Start time.Duration
+	End   time.Duration
+	// Matches are the bad words of the cue, with offsets in its text with
+	// the lines joined by "\n" and the styling tags removed.
+	Matches []Match
+}
+
+// CensorSRT censors the text of the SubRip subtitles read from r and
+// writes them to w, keeping the numbering, the timings and the styling
+// tags like <i> or {\an8}. The lines of a cue are matched as a single text,
+// so words broken across lines are caught. It returns the cues that had
+// bad words, so the audio can be bleeped at the same times.
+func (c *Censor) CensorSRT(r io.Reader, w io.Writer, lang string) ([]Cue, error) {
+	return c.censorSubtitles(r, w, lang, false)
+}
+
+// CensorWebVTT censors the text of the WebVTT subtitles read from r and
+// writes them to w like CensorSRT does. The header, NOTE, STYLE and REGION
+// blocks, cue identifiers and cue settings are kept as they are.
+func (c *Censor) CensorWebVTT(r io.Reader, w io.Writer, lang string) ([]Cue, error) {
+	return c.censorSubtitles(r, w, lang, true)
+}
+
+func (c *Censor) censorSubtitles(r io.Reader, w io.Writer, lang string, webVTT bool) ([]Cue, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	lines := splitLines(data)
+
+	var (
+		out  bytes.Buffer
+		cues []Cue
+		// header marks the WebVTT header block not seen yet
+		header = webVTT
+	)
+	for i := 0; i < len(lines); {
+		if isBlankLine(lines[i]) {
+			out.Write(lines[i])
+			i++
+			continue
+		}
+
+		// a block runs up to the next blank line
+		start := i
+		for i < len(lines) && !isBlankLine(lines[i]) {
+			i++
+		}
+		block := lines[start:i]
+
+		timing := -1
+		for j := 0; j < len(block) && j < 2; j++ {
+			if bytes.Contains(block[j], []byte("-->")) {
+				timing = j
+				break
+			}
+		}
+		if timing < 0 || header {
+			// the WebVTT header, a NOTE, STYLE or REGION block, or
+			// something that is not a cue
+			for _, line := range block {
+				out.Write(line)
+			}
+			header = false
+			continue
+		}
+
+		cue, err := parseCueTiming(string(block[timing]))
+		if err != nil {
+			return cues, fmt.Errorf("ugucensor: subtitles line %d: %w", start+timing+1, err)
+		}
+		for _, line := range block[:timing+1] {
+			out.Write(line)
+		}
+
+		text := block[timing+1:]
+		texts := make([]string, len(text))
+		for j, line := range text {
+			texts[j] = string(trimLineEnd(line))
+		}
+		censored, matches := c.censorTagged(strings.Join(texts, "\n"), lang)
+		for j, line := range strings.Split(censored, "\n") {
+			out.WriteString(line)
+			out.Write(text[j][len(trimLineEnd(text[j])):])
+		}
+		if len(matches) > 0 {
+			cue.Matches = matches
+			cues = append(cues, cue)
+		}
+	}
+
+	_, err = w.Write(out.Bytes())
+	return cues, err
+}
+
+// parseCueTiming parses a timing line like "00:00:01,000 --> 00:00:02,500"
+// or "01:02.000 --> 01:03.500 align:start".
+func parseCueTiming(line string) (Cue, error) {
+	fields := strings.Fields(line)
+	if len(fields) < 3 || fields[1] != "-->" {
+		return Cue{}, fmt.Errorf("malformed cue timing %q", line)
+	}
+	start, err := parseCueTime(fields[0])
+	if err != nil {
+		return Cue{}, err
+	}
+	end, err := parseCueTime(fields[2])
+	if err != nil {
+		return Cue{}, err
+	}
+	return Cue{Start: start, End: end}, nil
+}
+
+// parseCueTime parses a timestamp like "00:00:01,000" or "01:02.500".
+func parseCueTime(s string) (time.Duration, error) {
+	clock, frac, ok := strings.Cut(strings.Replace(s, ",", ".", 1), ".")
+	parts := strings.Split(clock, ":")
+	if !ok || len(frac) != 3 || len(parts) < 2 || len(parts) > 3 {
+		return 0, fmt.Errorf("malformed timestamp %q", s)
+	}
+
+	var d time.Duration
+	for _, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 {
+			return 0, fmt.Errorf("malformed timestamp %q", s)
+		}
+		d = d*60 + time.Duration(n)*time.Second
+	}
+	ms, err := strconv.Atoi(frac)
+	if err != nil || ms < 0 {
+		return 0, fmt.Errorf("malformed timestamp %q", s)
+	}
+	return d + time.Duration(ms)*time.Millisecond, nil
+}
+
+// censorTagged censors text with styling tags like <i>, <c.yellow> or
+// {\an8}, matching words across the tags and line breaks and leaving them
+// intact. The matches are returned with offsets in the text without the
+// tags.
+func (c *Censor) censorTagged(text string, lang string) (string, []Match) {
+	runes := []rune(text)
+
+	var (
+		plain     []rune
+		positions []int
+	)
+	for i := 0; i < len(runes); i++ {
+		if closing, ok := tagEnd(runes, i); ok {
+			i = closing
+			continue
+		}
+		plain = append(plain, runes[i])
+		positions = append(positions, i)
+	}
+
+	matches := c.findMatches(plain, lang)
+	if len(matches) == 0 && !c.stripInvisible {
+		return text, nil
+	}
+
+	// a masked grapheme cluster is written as an asterisk at its first
+	// rune, the rest of it is dropped
+	clusterStart := make([]bool, len(plain))
+	g := uniseg.NewGraphemes(string(plain))
+	for pos := 0; g.Next(); {
+		clusterStart[pos] = true
+		pos += len(g.Runes())
+	}
+
+	var result strings.Builder
+	masked := make([]bool, len(runes))
+	first := make([]bool, len(runes))
+	for _, m := range matches {
+		for j := m.Start; j < m.End; j++ {
+			// line breaks are kept, so the cue keeps its lines
+			pos := positions[j]
+			masked[pos] = runes[pos] != '\n'
+			first[pos] = clusterStart[j]
+		}
+	}
+	for i, ch := range runes {
+		switch {
+		case masked[i] && (isInvisible(ch) || !first[i]), !masked[i] && c.stripInvisible && isInvisible(ch):
+		case masked[i]:
+			result.WriteByte('*')
+		default:
+			result.WriteRune(ch)
+		}
+	}
+	return result.String(), matches
+}
+
+// tagEnd returns the position of the end of the styling tag starting at
+// i, if there is one.
+func tagEnd(runes []rune, i int) (int, bool) {
+	var closing rune
+	switch {
+	case runes[i] == '<':
+		closing = '>'
+	case runes[i] == '{' && i+1 < len(runes) && runes[i+1] == '\\':
+		closing = '}'
+	default:
+		return 0, false
+	}
+	for j := i + 1; j < len(runes) && runes[j] != '\n'; j++ {
+		if runes[j] == closing {
+			return j, true
+		}
+	}
+	return 0, false
+}
+
+// splitLines splits data into lines keeping their line endings.
+func splitLines(data []byte) [][]byte {
+	var lines [][]byte
+	for len(data) > 0 {
+		end := bytes.IndexByte(data, '\n') + 1
+		if end == 0 {
+			end = len(data)
+		}
+		lines = append(lines, data[:end])
+		data = data[end:]
+	}
+	return lines
+}
+
+func trimLineEnd(line []byte) []byte {
+	return bytes.TrimRight(line, "\r\n")
+}
+
+func isBlankLine(line []byte) bool {
+	return len(bytes.TrimSpace(line)) == 0
+}
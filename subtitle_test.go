@@ -0,0 +1,138 @@
+package ugucensor
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCensor_CensorSRT(t *testing.T) {
+	c := NewCensor()
+	c.AddWords([]string{"игра", "яблоко"}, "ru")
+
+	src := "1\r\n" +
+		"00:00:01,000 --> 00:00:02,500\r\n" +
+		"Это <i>игра</i>!\r\n" +
+		"\r\n" +
+		"2\r\n" +
+		"00:00:03,000 --> 00:00:04,000 X1:10 X2:20\r\n" +
+		"{\\an8}Привет,\r\n" +
+		"мир\r\n" +
+		"\r\n" +
+		"3\r\n" +
+		"01:00:05,250 --> 01:00:07,000\r\n" +
+		"сладкое ябл-\r\n" +
+		"<b>око</b>\r\n"
+	expected := "1\r\n" +
+		"00:00:01,000 --> 00:00:02,500\r\n" +
+		"Это <i>****</i>!\r\n" +
+		"\r\n" +
+		"2\r\n" +
+		"00:00:03,000 --> 00:00:04,000 X1:10 X2:20\r\n" +
+		"{\\an8}Привет,\r\n" +
+		"мир\r\n" +
+		"\r\n" +
+		"3\r\n" +
+		"01:00:05,250 --> 01:00:07,000\r\n" +
+		"сладкое ****\r\n" +
+		"<b>***</b>\r\n"
+
+	var out strings.Builder
+	cues, err := c.CensorSRT(strings.NewReader(src), &out, "ru")
+	if err != nil {
+		t.Fatalf("CensorSRT() error: %v", err)
+	}
+	if got := out.String(); got != expected {
+		t.Errorf("CensorSRT() = %q; want %q", got, expected)
+	}
+
+	if len(cues) != 2 {
+		t.Fatalf("CensorSRT() cues = %+v; want 2 cues", cues)
+	}
+	f := func(cue Cue, start, end time.Duration, entry string) {
+		t.Helper()
+
+		if cue.Start != start || cue.End != end || len(cue.Matches) != 1 || cue.Matches[0].Entry != entry {
+			t.Errorf("cue = %+v; want %v --> %v with %q", cue, start, end, entry)
+		}
+	}
+	f(cues[0], time.Second, 2500*time.Millisecond, "игра")
+	f(cues[1], time.Hour+5250*time.Millisecond, time.Hour+7*time.Second, "яблоко")
+}
+
+func TestCensor_CensorWebVTT(t *testing.T) {
+	c := NewCensor()
+	c.AddWord("игра", "ru")
+
+	src := "WEBVTT - игра\n" +
+		"\n" +
+		"NOTE игра\n" +
+		"\n" +
+		"STYLE\n" +
+		"::cue(.игра) { color: red }\n" +
+		"\n" +
+		"игра-1\n" +
+		"00:01.000 --> 00:02.000 align:start\n" +
+		"<v Игрок>Моя <c.yellow>игра</c>\n" +
+		"\n" +
+		"00:00:03.000 --> 00:00:04.000\n" +
+		"<00:00:03.500>мир"
+	expected := "WEBVTT - игра\n" +
+		"\n" +
+		"NOTE игра\n" +
+		"\n" +
+		"STYLE\n" +
+		"::cue(.игра) { color: red }\n" +
+		"\n" +
+		"игра-1\n" +
+		"00:01.000 --> 00:02.000 align:start\n" +
+		"<v Игрок>Моя <c.yellow>****</c>\n" +
+		"\n" +
+		"00:00:03.000 --> 00:00:04.000\n" +
+		"<00:00:03.500>мир"
+
+	var out strings.Builder
+	cues, err := c.CensorWebVTT(strings.NewReader(src), &out, "ru")
+	if err != nil {
+		t.Fatalf("CensorWebVTT() error: %v", err)
+	}
+	if got := out.String(); got != expected {
+		t.Errorf("CensorWebVTT() = %q; want %q", got, expected)
+	}
+	if len(cues) != 1 || cues[0].Start != time.Second || cues[0].End != 2*time.Second {
+		t.Errorf("CensorWebVTT() cues = %+v; want one cue at 1s --> 2s", cues)
+	}
+}
+
+func TestCensor_CensorSRT_Errors(t *testing.T) {
+	c := NewCensor()
+	c.AddWord("игра", "ru")
+
+	f := func(src string) {
+		t.Helper()
+
+		var out strings.Builder
+		if _, err := c.CensorSRT(strings.NewReader(src), &out, "ru"); err == nil {
+			t.Errorf("CensorSRT(%q) = nil error; want an error", src)
+		}
+	}
+
+	f("1\n00:00:01 --> 00:00:02,000\nигра\n")
+	f("1\n00:00:01,000 -> 00:00:02,000 --> x\nигра\n")
+	f("1\naa:00:01,000 --> 00:00:02,000\nигра\n")
+}
+
+func TestParseCueTime(t *testing.T) {
+	f := func(s string, expected time.Duration) {
+		t.Helper()
+
+		got, err := parseCueTime(s)
+		if err != nil || got != expected {
+			t.Errorf("parseCueTime(%q) = %v, %v; want %v", s, got, err, expected)
+		}
+	}
+
+	f("00:00:01,000", time.Second)
+	f("01:02:03.004", time.Hour+2*time.Minute+3*time.Second+4*time.Millisecond)
+	f("02:03.500", 2*time.Minute+3500*time.Millisecond)
+}
@@ -0,0 +1,169 @@
+package ugucensor
+
+import (
+	"sort"
+	"unicode"
+)
+
+// translitRule maps a Latin sequence to the letters it may stand for.
+type translitRule struct {
+	latin    []rune
+	cyrillic []rune
+}
+
+// translitTables holds the Latin to Cyrillic transliteration rules used by
+// WithTransliteration. They cover GOST and ISO 9 as well as the informal
+// schemes people type in chats ("ya", "ja" and "ia" for "я", "w" for "ш").
+var translitTables = map[string][]translitRule{
+	"ru": newTranslitTable(map[string]string{
+		"shch": "щ", "sch": "щ", "sh": "ш", "ch": "чх", "zh": "ж", "kh": "х",
+		"ts": "ц", "tz": "ц", "cz": "ч",
+		"ya": "я", "ja": "я", "ia": "я",
+		"yu": "ю", "ju": "ю", "iu": "ю",
+		"yo": "ё", "jo": "ё", "io": "ё",
+		"ye": "е", "je": "е",
+		"yi": "ы", "y": "ыйи", "i": "ий", "e": "еэ",
+		"a": "а", "b": "б", "v": "в", "w": "вш", "g": "г", "d": "д", "z": "з",
+		"k": "к", "l": "л", "m": "м", "n": "н", "o": "о", "p": "п", "r": "р",
+		"s": "с", "t": "т", "u": "у", "f": "ф", "h": "х", "c": "цк", "x": "х",
+		"q": "к", "j": "йж",
+	}),
+}
+
+func newTranslitTable(rules map[string]string) []translitRule {
+	table := make([]translitRule, 0, len(rules))
+	for latin, cyrillic := range rules {
+		table = append(table, translitRule{[]rune(latin), []rune(cyrillic)})
+	}
+	// longer sequences first, so "sh" is tried before "s"
+	sort.Slice(table, func(i, j int) bool {
+		if len(table[i].latin) != len(table[j].latin) {
+			return len(table[i].latin) > len(table[j].latin)
+		}
+		return string(table[i].latin) < string(table[j].latin)
+	})
+	return table
+}
+
+// maxTranslitCandidates caps the number of Cyrillic spellings checked for
+// one word, as every ambiguous letter doubles them.
+const maxTranslitCandidates = 256
+
+// findTranslitMatches finds words typed in Latin letters that spell a
+// dictionary word of the language when transliterated: "igra" for "игра".
+// With WithWordSplitting the camelCase parts are checked on their own.
+func (c *Censor) findTranslitMatches(text normalized, lang string, found []Match) []Match {
+	table, ok := translitTables[lang]
+	if !ok {
+		return nil
+	}
+
+	var matches []Match
+	for _, tok := range uncoveredTokens(c.wordParts(text), found) {
+		word := text.runes[tok.start:tok.end]
+		if !isLatin(word) {
+			continue
+		}
+		for _, candidate := range c.transliterate(word, table, lang) {
+			if m, ok := c.matchWord(candidate, lang); ok {
+				m.Start, m.End = tok.start, tok.end
+				m.Kind = MatchTranslit
+				matches = append(matches, m)
+				break
+			}
+		}
+	}
+	return matches
+}
+
+// transliterate returns the Cyrillic spellings of the Latin word that may
+// be dictionary words, skipping the ones leaving the trie before reaching
+// an entry.
+func (c *Censor) transliterate(word []rune, table []translitRule, lang string) [][]rune {
+	var (
+		candidates [][]rune
+		built      []rune
+		dict       = c.dicts[lang]
+		folds      = c.folds[lang]
+	)
+
+	var walk func(pos int, passedEntry bool)
+	walk = func(pos int, passedEntry bool) {
+		if len(candidates) >= maxTranslitCandidates {
+			return
+		}
+		if !passedEntry && len(built) > 0 {
+			hasPrefix, isEnd := dict.StartsWith(string(built))
+			if !hasPrefix {
+				return
+			}
+			passedEntry = isEnd
+		}
+		if pos == len(word) {
+			if passedEntry {
+				candidates = append(candidates, append([]rune(nil), built...))
+			}
+			return
+		}
+
+		for _, rule := range table {
+			if !hasRunesAt(word, pos, rule.latin) {
+				continue
+			}
+			for _, ch := range rule.cyrillic {
+				if folded, ok := folds[ch]; ok {
+					ch = folded
+				}
+				built = append(built, ch)
+				walk(pos+len(rule.latin), passedEntry)
+				built = built[:len(built)-1]
+			}
+		}
+	}
+	walk(0, false)
+
+	return candidates
+}
+
+// matchWord checks a single word against the dictionary with the same
+// stem path as CensorText.
+func (c *Censor) matchWord(word []rune, lang string) (Match, bool) {
+	for _, wb := range c.findPossibleBadWordBounds(word, []int{0}, lang) {
+		if wb.End != len(word) {
+			continue
+		}
+		if e, ok := c.lookup(wb.Word, wb.BadPart, lang); ok {
+			return Match{
+				Start:      0,
+				End:        len(word),
+				Word:       wb.Word,
+				Entry:      e.word,
+				Severity:   e.severity,
+				Category:   e.category,
+				Confidence: 1,
+			}, true
+		}
+	}
+	return Match{}, false
+}
+
+func hasRunesAt(word []rune, pos int, seq []rune) bool {
+	if pos+len(seq) > len(word) {
+		return false
+	}
+	for i, ch := range seq {
+		if word[pos+i] != ch {
+			return false
+		}
+	}
+	return true
+}
+
+func isLatin(word []rune) bool {
+	for _, ch := range word {
+		if !unicode.Is(unicode.Latin, ch) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,45 @@
+package ugucensor
+
+import "testing"
+
+func TestCensor_CensorText_Transliteration(t *testing.T) {
+	c := NewCensor(WithTransliteration())
+	c.AddWords([]string{"игра", "яблоко", "шишка", "жучок", "щука", "ёлка", "чай"}, "ru")
+
+	f := func(text string, expected string, expectedCensored bool) {
+		t.Helper()
+
+		got, gotCensored := c.CensorText(text, "ru")
+		if got != expected || gotCensored != expectedCensored {
+			t.Errorf("\nCensorText(%q, \"ru\")\n\tgot : %s, %v\n\twant: %s, %v", text, got, gotCensored, expected, expectedCensored)
+		}
+	}
+
+	t.Run("translit", func(t *testing.T) {
+		f("eto igra", "eto ****", true)
+		f("IGRY i yabloki", "**** i *******", true)
+		f("jabloko, iabloko", "*******, *******", true)
+		f("shishka", "*******", true)
+		f("zhuchok", "*******", true)
+		f("shchuka i schuka", "******* i ******", true)
+		f("yolka, elka", "*****, ****", true)
+		f("chay", "****", true)
+	})
+
+	t.Run("mixed with cyrillic", func(t *testing.T) {
+		f("игра и igra", "**** и ****", true)
+	})
+
+	t.Run("no false positives", func(t *testing.T) {
+		f("ignore the game", "ignore the game", false)
+		f("ugra", "ugra", false)
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		c := NewCensor()
+		c.AddWord("игра", "ru")
+		if got, _ := c.CensorText("igra", "ru"); got != "igra" {
+			t.Errorf("CensorText(%q, \"ru\") = %q; want %q", "igra", got, "igra")
+		}
+	})
+}
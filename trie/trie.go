@@ -1,5 +1,7 @@
 package trie
 
+import "sort"
+
 type trieNode struct {
 	children map[rune]*trieNode
 	isEnd    bool
@@ -112,6 +114,78 @@ func (t *Trie) remove(node *trieNode, word string, index int) bool {
 	return false
 }
 
+// FuzzyMatch is a word found by FuzzySearch.
+type FuzzyMatch struct {
+	Word     string
+	Distance int
+}
+
+// FuzzySearch returns the words in the trie within the given edit distance
+// of the word, ordered by distance and then alphabetically.
+// The distance is the optimal string alignment distance: insertions,
+// deletions, substitutions and transpositions of adjacent characters each
+// count as one edit.
+//
+// Example:
+//
+//	trie := NewTrie()
+//	trie.Insert("apple")
+//	trie.FuzzySearch("aple", 1)  // [{apple 1}]
+//	trie.FuzzySearch("appel", 1) // [{apple 1}]
+//	trie.FuzzySearch("ale", 1)   // []
+func (t *Trie) FuzzySearch(word string, maxDist int) []FuzzyMatch {
+	target := []rune(word)
+
+	// row[i] is the distance between the current trie prefix and target[:i]
+	row := make([]int, len(target)+1)
+	for i := range row {
+		row[i] = i
+	}
+
+	var matches []FuzzyMatch
+	var prefix []rune
+	var walk func(node *trieNode, prev, row []int)
+	walk = func(node *trieNode, prev, row []int) {
+		if node.isEnd && row[len(target)] <= maxDist {
+			matches = append(matches, FuzzyMatch{string(prefix), row[len(target)]})
+		}
+
+		for ch, child := range node.children {
+			prefix = append(prefix, ch)
+			next := make([]int, len(target)+1)
+			next[0] = row[0] + 1
+			best := next[0]
+			for i := 1; i <= len(target); i++ {
+				cost := 1
+				if target[i-1] == ch {
+					cost = 0
+				}
+				next[i] = min(next[i-1]+1, row[i]+1, row[i-1]+cost)
+				// transposition of adjacent characters
+				if prev != nil && i > 1 && len(prefix) > 1 &&
+					target[i-1] == prefix[len(prefix)-2] && target[i-2] == ch {
+					next[i] = min(next[i], prev[i-2]+1)
+				}
+				best = min(best, next[i])
+			}
+			// no word below this node can get closer than the best cell
+			if best <= maxDist {
+				walk(child, row, next)
+			}
+			prefix = prefix[:len(prefix)-1]
+		}
+	}
+	walk(t.root, nil, row)
+
+	sort.Slice(matches, func(i, j int) bool {
+		if matches[i].Distance != matches[j].Distance {
+			return matches[i].Distance < matches[j].Distance
+		}
+		return matches[i].Word < matches[j].Word
+	})
+	return matches
+}
+
 func (t *Trie) Cursor() *TrieCursor {
 	return NewTrieCursor(t.root)
 }
@@ -152,6 +226,27 @@ func (cursor *TrieCursor) Advance(ch rune) (bool, bool) {
 	return false, false
 }
 
+// Clone returns a new cursor at the same position, so a traversal can branch
+// without losing its place.
+func (cursor *TrieCursor) Clone() *TrieCursor {
+	return &TrieCursor{
+		root:    cursor.root,
+		current: cursor.current,
+	}
+}
+
+// Next returns the characters the cursor can advance by, in ascending order.
+func (cursor *TrieCursor) Next() []rune {
+	next := make([]rune, 0, len(cursor.current.children))
+	for ch := range cursor.current.children {
+		next = append(next, ch)
+	}
+	sort.Slice(next, func(i, j int) bool {
+		return next[i] < next[j]
+	})
+	return next
+}
+
 // Reset repositions the cursor back to the root of the trie.
 // This method is useful for restarting a traversal from the beginning of the trie
 // without creating a new TrieCursor instance.
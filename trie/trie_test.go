@@ -141,3 +141,82 @@ func TestTrie_Cursor(t *testing.T) {
 	f('n', true, false)
 	f('d', true, true)
 }
+
+func TestTrie_FuzzySearch(t *testing.T) {
+	trie := NewTrie()
+
+	wordsToInsert := []string{"apple", "app", "apply", "banana", "band", "bandit"}
+	for _, word := range wordsToInsert {
+		trie.Insert(word)
+	}
+
+	f := func(word string, maxDist int, expected ...FuzzyMatch) {
+		t.Helper()
+
+		got := trie.FuzzySearch(word, maxDist)
+		if len(got) != len(expected) {
+			t.Errorf("FuzzySearch(%q, %d) = %v; want %v", word, maxDist, got, expected)
+			return
+		}
+		for i := range got {
+			if got[i] != expected[i] {
+				t.Errorf("FuzzySearch(%q, %d) = %v; want %v", word, maxDist, got, expected)
+				return
+			}
+		}
+	}
+
+	f("apple", 0, FuzzyMatch{"apple", 0})
+	f("apple", 1, FuzzyMatch{"apple", 0}, FuzzyMatch{"apply", 1})
+	f("aple", 1, FuzzyMatch{"apple", 1})
+	f("appel", 1, FuzzyMatch{"apple", 1})
+	f("paple", 1, FuzzyMatch{"apple", 1})
+	f("pxple", 1)
+	f("bnad", 1, FuzzyMatch{"band", 1})
+	f("bandits", 1, FuzzyMatch{"bandit", 1})
+	f("bandits", 2, FuzzyMatch{"bandit", 1})
+	f("ap", 1, FuzzyMatch{"app", 1})
+	f("xyz", 1)
+	f("", 3, FuzzyMatch{"app", 3})
+}
+
+func TestTrie_CursorBranching(t *testing.T) {
+	trie := NewTrie()
+
+	wordsToInsert := []string{"band", "bend", "bind", "bandit"}
+	for _, word := range wordsToInsert {
+		trie.Insert(word)
+	}
+
+	cursor := trie.Cursor()
+	cursor.Advance('b')
+
+	if got := string(cursor.Next()); got != "aei" {
+		t.Errorf("Next() = %q; want %q", got, "aei")
+	}
+
+	clone := cursor.Clone()
+	if ok, _ := clone.Advance('e'); !ok {
+		t.Errorf("clone.Advance('e') = false; want true")
+	}
+	if got := string(clone.Next()); got != "n" {
+		t.Errorf("clone.Next() = %q; want %q", got, "n")
+	}
+
+	// the original cursor stays in place
+	if ok, _ := cursor.Advance('a'); !ok {
+		t.Errorf("cursor.Advance('a') = false; want true")
+	}
+
+	cursor.Advance('n')
+	cursor.Advance('d')
+	if got := string(cursor.Next()); got != "i" {
+		t.Errorf("Next() = %q; want %q", got, "i")
+	}
+
+	cursor.Advance('i')
+	cursor.Advance('t')
+	if got := cursor.Next(); len(got) != 0 {
+		t.Errorf("Next() = %q; want empty", string(got))
+	}
+}
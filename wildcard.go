@@ -0,0 +1,98 @@
+package ugucensor
+
+import (
+	"unicode"
+
+	"github.com/machine23/ugu-censor/trie"
+)
+
+// defaultWildcards are the symbols standing for a hidden letter when
+// WithWildcards is given none.
+var defaultWildcards = []rune{'*', '#', '_'}
+
+// findWildcardMatches finds self-censored words like "и*ра" or "ябл#ко",
+// where wildcard symbols inside a word hide single letters, and checks
+// whether the visible letters still spell a dictionary word. The matches
+// cover the whole word, so they take over the shorter ones found before
+// the wildcard ("игр" in "игр*к").
+func (c *Censor) findWildcardMatches(runes []rune, lang string) []Match {
+	var matches []Match
+	for _, tok := range c.wildcardTokens(runes) {
+		word := runes[tok.start:tok.end]
+		if m, ok := c.walkWildcards(word, 0, c.dicts[lang].Cursor(), nil, lang); ok {
+			m.Start, m.End = tok.start, tok.end
+			m.Kind = MatchSelfCensored
+			matches = append(matches, m)
+		}
+	}
+	return matches
+}
+
+// walkWildcards walks the trie with the word from pos, branching across all
+// children of the cursor on a wildcard, and checks every spelling with the
+// stem path. A nil cursor means the walk has left the trie: a wildcard
+// there hides a letter of the ending, so the letters before it are checked.
+func (c *Censor) walkWildcards(word []rune, pos int, cursor *trie.TrieCursor, built []rune, lang string) (Match, bool) {
+	if pos == len(word) {
+		return c.matchWord(built, lang)
+	}
+
+	ch := word[pos]
+	if !c.isWildcard(ch) {
+		if cursor != nil {
+			cursor = cursor.Clone()
+			if ok, _ := cursor.Advance(ch); !ok {
+				cursor = nil
+			}
+		}
+		return c.walkWildcards(word, pos+1, cursor, append(built, ch), lang)
+	}
+
+	if cursor == nil {
+		return c.matchWord(built, lang)
+	}
+	for _, next := range cursor.Next() {
+		branch := cursor.Clone()
+		branch.Advance(next)
+		spelling := append(built[:len(built):len(built)], next)
+		if m, ok := c.walkWildcards(word, pos+1, branch, spelling, lang); ok {
+			return m, true
+		}
+	}
+	return Match{}, false
+}
+
+// wildcardTokens returns the runs of letters and wildcards with at most
+// maxWildcards wildcards, all of them between letters.
+func (c *Censor) wildcardTokens(runes []rune) []token {
+	var tokens []token
+	for i := 0; i < len(runes); i++ {
+		if !unicode.IsLetter(runes[i]) {
+			continue
+		}
+		start, end, wildcards := i, i, 0
+		for ; i < len(runes) && (unicode.IsLetter(runes[i]) || c.isWildcard(runes[i])); i++ {
+			if unicode.IsLetter(runes[i]) {
+				end = i + 1
+			}
+		}
+		for _, ch := range runes[start:end] {
+			if c.isWildcard(ch) {
+				wildcards++
+			}
+		}
+		if wildcards > 0 && wildcards <= c.maxWildcards {
+			tokens = append(tokens, token{start, end})
+		}
+	}
+	return tokens
+}
+
+func (c *Censor) isWildcard(ch rune) bool {
+	for _, wildcard := range c.wildcards {
+		if ch == wildcard {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,57 @@
+package ugucensor
+
+import "testing"
+
+func TestCensor_CensorText_Wildcards(t *testing.T) {
+	c := NewCensor(WithWildcards(2))
+	c.AddWords([]string{"игра", "игрок", "яблоко"}, "ru")
+
+	f := func(text string, expected string, expectedCensored bool) {
+		t.Helper()
+
+		got, gotCensored := c.CensorText(text, "ru")
+		if got != expected || gotCensored != expectedCensored {
+			t.Errorf("\nCensorText(%q, \"ru\")\n\tgot : %s, %v\n\twant: %s, %v", text, got, gotCensored, expected, expectedCensored)
+		}
+	}
+
+	t.Run("self-censored words", func(t *testing.T) {
+		f("это и*ра", "это ****", true)
+		f("сочное ябл#ко!", "сочное ******!", true)
+		f("и_р_к", "*****", true)
+		f("игр*к и и#ры", "***** и ****", true)
+		f("иг*ы", "****", true)
+		f("яб**ко", "******", true)
+	})
+
+	t.Run("wildcards limit", func(t *testing.T) {
+		f("я***ко", "я***ко", false)
+	})
+
+	t.Run("wildcards outside words", func(t *testing.T) {
+		f("*игра*", "******", true)
+		f("#тег и*", "#тег и*", false)
+	})
+
+	t.Run("no false positives", func(t *testing.T) {
+		f("к*т", "к*т", false)
+		f("и*рушка", "и*рушка", false)
+		f("у*ра", "у*ра", false)
+	})
+
+	t.Run("match kind", func(t *testing.T) {
+		matches := c.FindMatches("и*ра", "ru")
+		if len(matches) != 1 || matches[0].Kind != MatchSelfCensored || matches[0].Word != "игра" {
+			t.Errorf("FindMatches(%q) = %+v; want one self-censored match of %q", "и*ра", matches, "игра")
+		}
+	})
+
+	t.Run("custom symbols", func(t *testing.T) {
+		c := NewCensor(WithWildcards(1, '?'))
+		c.AddWord("игра", "ru")
+
+		if got, _ := c.CensorText("и?ра и*ра", "ru"); got != "**** и*ра" {
+			t.Errorf("CensorText(%q) = %q; want %q", "и?ра и*ра", got, "**** и*ра")
+		}
+	})
+}